@@ -0,0 +1,14 @@
+//go:build flisdk
+
+package main
+
+import (
+	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+	"github.com/New-Earth-Lab/flicameraservice/internal/fli"
+)
+
+func init() {
+	newFliBackend = func() (app.CameraBackend, error) {
+		return fli.NewBackend()
+	}
+}
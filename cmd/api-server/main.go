@@ -3,119 +3,486 @@ package main
 import (
 	"context"
 	"flag"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-faster/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/New-Earth-Lab/flicameraservice/internal/api"
 	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+	"github.com/New-Earth-Lab/flicameraservice/internal/oas"
+	"github.com/New-Earth-Lab/flicameraservice/internal/replay"
+	"github.com/New-Earth-Lab/flicameraservice/internal/sim"
 	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/archive"
 )
 
+// newFliBackend creates the FLI SDK backend. It is only set when built with
+// the flisdk build tag, so that the other backends build without cgo and
+// the SDK.
+var newFliBackend func() (app.CameraBackend, error)
+
 func main() {
 	app.Run(func(ctx context.Context, lg *zap.Logger) error {
 		var arg struct {
 			Addr               string
 			MetricsAddr        string
+			Exporter           string
 			AeronUri           string
 			AeronStreamId      int
+			AeronAutoTerm      bool
+			AeronDir           string
+			AeronDriverTimeout time.Duration
+			AeronCounters      time.Duration
+			ControlChannel     string
+			ControlStreamId    int
+			ControlResponse    string
+			ControlResponseId  int
+			ArchiveRequest     string
+			ArchiveRequestId   int
+			ArchiveResponse    string
+			ArchiveResponseId  int
+			ArchiveReplay      string
+			ArchiveReplayId    int
+			PublishPolicy      string
+			PublishTimeout     time.Duration
+			PublishQueue       int
+			PublishCPUs        string
+			PublishPause       bool
+			Outputs            stringList
 			CameraSerialNumber string
 			Width              int
 			Height             int
 			OffsetX            int
 			OffsetY            int
+			FrameRate          float64
+			IntegrationTime    float64
+			SensorSetpoint     float64
+			ThermalInterval    time.Duration
+			ThermalTolerance   float64
+			ThermalWindow      time.Duration
+			Camera             string
+			SimFPS             float64
+			SimPattern         string
+			ReplayPath         string
+			ReplayPacing       string
+			ReplayFPS          float64
+			ReplayLoop         bool
 		}
-		// flag.StringVar(&arg.Addr, "addr", "127.0.0.1:8080", "listen address")
-		// flag.StringVar(&arg.MetricsAddr, "metrics.addr", "127.0.0.1:9090", "metrics listen address")
+		flag.StringVar(&arg.Addr, "addr", "127.0.0.1:8080", "listen address")
+		flag.StringVar(&arg.MetricsAddr, "metrics.addr", "127.0.0.1:9090", "metrics listen address")
+		flag.StringVar(&arg.Exporter, "otel.exporter", app.ExporterNone, "OpenTelemetry exporter: none, stdout or otlp")
 		flag.StringVar(&arg.AeronUri, "aeron.Uri", "aeron:ipc", "Aeron channel URI")
 		flag.IntVar(&arg.AeronStreamId, "aeron.StreamId", 1001, "Aeron stream ID")
+		flag.StringVar(&arg.AeronDir, "aeron.dir", "", "Aeron media driver directory, empty for the default")
+		flag.DurationVar(&arg.AeronDriverTimeout, "aeron.driverTimeout", app.DefaultDriverTimeout, "Time without a media driver heartbeat after which the service reconnects")
+		flag.DurationVar(&arg.AeronCounters, "aeron.counterInterval", app.DefaultCounterInterval, "Update interval of the camera counters allocated on the media driver, 0 disables them")
+		flag.StringVar(&arg.ControlChannel, "control.channel", "", "Aeron channel of the camera commands, empty disables the command stream")
+		flag.IntVar(&arg.ControlStreamId, "control.stream", 1010, "Aeron stream ID of the camera commands")
+		flag.StringVar(&arg.ControlResponse, "control.responseChannel", "", "Aeron channel of the command responses, empty for -control.channel")
+		flag.IntVar(&arg.ControlResponseId, "control.responseStream", 1011, "Aeron stream ID of the command responses")
+		flag.StringVar(&arg.ArchiveRequest, "archive.requestChannel", "", "Aeron Archive control request channel, empty disables recording")
+		flag.IntVar(&arg.ArchiveRequestId, "archive.requestStream", 10, "Aeron Archive control request stream ID")
+		flag.StringVar(&arg.ArchiveResponse, "archive.responseChannel", "aeron:udp?endpoint=localhost:0", "Aeron Archive control response channel")
+		flag.IntVar(&arg.ArchiveResponseId, "archive.responseStream", 20, "Aeron Archive control response stream ID")
+		flag.StringVar(&arg.ArchiveReplay, "archive.replayChannel", "aeron:ipc", "Aeron channel the archive replays recordings to the service on")
+		flag.IntVar(&arg.ArchiveReplayId, "archive.replayStream", 1099, "Aeron stream ID the archive replays recordings to the service on")
+		flag.BoolVar(&arg.AeronAutoTerm, "aeron.autoTermLength", false, "Set the term-length of output channels whose max message length is too short for the frames")
+		flag.StringVar(&arg.PublishPolicy, "publish.policy", app.DefaultPublishConfig.Policy.String(), "Back-pressure policy: drop-newest, spin or block")
+		flag.DurationVar(&arg.PublishTimeout, "publish.timeout", app.DefaultPublishConfig.Timeout, "Retry timeout of the spin back-pressure policy")
+		flag.IntVar(&arg.PublishQueue, "publish.queue", app.DefaultQueueLength, "Frames buffered between the camera callback and the publisher")
+		flag.Var(&arg.Outputs, "output", "Output as comma separated key=value pairs: name, channel, stream, decimation, policy and timeout, "+
+			"defaulting to the aeron and publish flags; repeat for several outputs, none publishes to -aeron.Uri")
+		flag.BoolVar(&arg.PublishPause, "publish.pauseWithoutSubscribers", false, "Stop acquisition while no output has subscribers")
+		flag.StringVar(&arg.PublishCPUs, "publish.cpus", "", "Comma separated CPUs to pin the publisher thread to, empty does not pin")
 		flag.StringVar(&arg.CameraSerialNumber, "serialNumber", "01-00001bb0cef0", "Camera Serial Number")
 		flag.IntVar(&arg.Width, "width", 640, "Image width")
 		flag.IntVar(&arg.Height, "height", 512, "Image height")
 		flag.IntVar(&arg.OffsetX, "offsetx", 0, "Image X offset")
 		flag.IntVar(&arg.OffsetY, "offsety", 0, "Image Y offset")
+		flag.Float64Var(&arg.FrameRate, "fps", 0, "Initial frame rate in Hz, 0 keeps the camera setting")
+		flag.Float64Var(&arg.IntegrationTime, "tint", 0, "Initial integration time in seconds, 0 keeps the camera setting")
+		flag.Float64Var(&arg.SensorSetpoint, "temperature.setpoint", math.NaN(), "Initial sensor cooling setpoint in degrees Celsius, unset keeps the camera setting")
+		flag.DurationVar(&arg.ThermalInterval, "temperature.interval", 10*time.Second, "Temperature logging interval, 0 disables logging")
+		flag.Float64Var(&arg.ThermalTolerance, "temperature.tolerance", 0.1, "Maximum sensor deviation from the setpoint in degrees Celsius to count as stable")
+		flag.DurationVar(&arg.ThermalWindow, "temperature.window", time.Minute, "Time the sensor must stay within tolerance to count as stable")
+		flag.StringVar(&arg.Camera, "camera", "fli", "Camera backend: fli, sim or replay")
+		flag.Float64Var(&arg.SimFPS, "sim.fps", 100, "Simulated camera frame rate")
+		flag.StringVar(&arg.SimPattern, "sim.pattern", "spots", "Simulated image pattern: ramp, noise, spots or counter")
+		flag.StringVar(&arg.ReplayPath, "replay.path", "", "FITS cube or directory of frames to replay, cropped to the region of interest")
+		flag.StringVar(&arg.ReplayPacing, "replay.pacing", "original", "Replay pacing: original, fps or fast")
+		flag.Float64Var(&arg.ReplayFPS, "replay.fps", 100, "Replay frame rate for fps pacing")
+		flag.BoolVar(&arg.ReplayLoop, "replay.loop", false, "Loop the replay")
 
 		flag.Parse()
 
 		lg.Info("Initializing",
-			// zap.String("http.addr", arg.Addr),
-			// zap.String("metrics.addr", arg.MetricsAddr),
-			zap.String("aeron.Uri", arg.AeronUri),
-			zap.Int("aeron.streamId", arg.AeronStreamId),
+			zap.String("http.addr", arg.Addr),
+			zap.String("metrics.addr", arg.MetricsAddr),
 			zap.String("serialNumber", arg.CameraSerialNumber),
+			zap.String("camera", arg.Camera),
 		)
 
-		// metrics, err := app.NewMetrics(lg, app.Config{
-		// 	Addr: arg.MetricsAddr,
-		// 	Name: "api",
-		// })
-		// if err != nil {
-		// 	return errors.Wrap(err, "metrics")
-		// }
-
-		// oasServer, err := oas.NewServer(api.Handler{},
-		// 	oas.WithTracerProvider(metrics.TracerProvider()),
-		// 	oas.WithMeterProvider(metrics.MeterProvider()),
-		// )
-		// if err != nil {
-		// 	return errors.Wrap(err, "server init")
-		// }
-		// httpServer := http.Server{
-		// 	Addr:    arg.Addr,
-		// 	Handler: oasServer,
-		// }
-
-		aeronContext := aeron.NewContext()
-
-		a, err := aeron.Connect(aeronContext)
+		policy, err := app.ParseBackPressurePolicy(arg.PublishPolicy)
 		if err != nil {
-			return errors.Wrap(err, "aeron connect")
+			return err
+		}
+		defaultOutput := app.OutputConfig{
+			Name:     "default",
+			Channel:  arg.AeronUri,
+			StreamID: int32(arg.AeronStreamId),
+			Publish: app.PublishConfig{
+				Policy:  policy,
+				Timeout: arg.PublishTimeout,
+			},
+		}
+		outputConfigs := []app.OutputConfig{defaultOutput}
+		if len(arg.Outputs) > 0 {
+			outputConfigs = outputConfigs[:0]
+			for i, s := range arg.Outputs {
+				defaultOutput.Name = "output" + strconv.Itoa(i)
+				c, err := app.ParseOutputConfig(s, defaultOutput)
+				if err != nil {
+					return err
+				}
+				outputConfigs = append(outputConfigs, c)
+			}
+		}
+
+		if arg.Width <= 0 || int64(arg.Width) > math.MaxUint32 || arg.Height <= 0 || int64(arg.Height) > math.MaxUint32 ||
+			arg.OffsetX < 0 || arg.OffsetX > math.MaxUint16 || arg.OffsetY < 0 || arg.OffsetY > math.MaxUint16 {
+			return errors.Errorf("invalid region of interest: %dx%d+%d+%d",
+				arg.Width, arg.Height, arg.OffsetX, arg.OffsetY)
 		}
-		defer a.Close()
 
-		publication, err := a.AddPublication(arg.AeronUri, int32(arg.AeronStreamId))
+		cpus, err := parseCPUs(arg.PublishCPUs)
 		if err != nil {
-			return errors.Wrap(err, "aeron AddPublication")
+			return err
+		}
+
+		metrics, err := app.NewMetrics(lg, app.Config{
+			Addr:     arg.MetricsAddr,
+			Name:     "api",
+			Exporter: arg.Exporter,
+		})
+		if err != nil {
+			return errors.Wrap(err, "metrics")
+		}
+
+		transport, err := app.NewTransport(lg, app.TransportConfig{
+			Dir:           arg.AeronDir,
+			DriverTimeout: arg.AeronDriverTimeout,
+		})
+		if err != nil {
+			return errors.Wrap(err, "aeron")
+		}
+		defer transport.Close()
+		a := transport.Client()
+
+		// The camera checks the frames fit the outputs, this only sizes the
+		// terms for the initial window
+		imageSize := int64(arg.Width) * int64(arg.Height) * 2
+		if max := int64(math.MaxInt32 - app.MaxImageHeaderLength); imageSize > max {
+			imageSize = max
+		}
+		messageLength := app.FrameMessageLength(int32(imageSize))
+		outputs := make([]*app.Output, 0, len(outputConfigs))
+		for _, c := range outputConfigs {
+			publication, err := a.AddPublication(c.Channel, c.StreamID)
+			if err != nil {
+				return errors.Wrapf(err, "aeron AddPublication %s", c.Name)
+			}
+			if max := app.MaxMessageLength(publication); arg.AeronAutoTerm && max > 0 && max < messageLength {
+				channel, err := app.ChannelWithTermLength(c.Channel, messageLength)
+				if err != nil {
+					publication.Close()
+					return errors.Wrapf(err, "output %s", c.Name)
+				}
+				publication.Close()
+				lg.Info("Term length raised for the frame size",
+					zap.String("output", c.Name),
+					zap.String("channel", channel),
+					zap.Int32("maxMessageLength", max),
+					zap.Int32("messageLength", messageLength),
+				)
+				c.Channel = channel
+				if publication, err = a.AddPublication(c.Channel, c.StreamID); err != nil {
+					return errors.Wrapf(err, "aeron AddPublication %s", c.Name)
+				}
+			}
+
+			output, err := app.NewOutput(lg, c, publication, transport.AddPublication)
+			if err != nil {
+				publication.Close()
+				return err
+			}
+			defer output.Close()
+			outputs = append(outputs, output)
+			lg.Info("Output",
+				zap.String("name", c.Name),
+				zap.String("channel", c.Channel),
+				zap.Int32("streamId", c.StreamID),
+				zap.Int("decimation", c.Decimation),
+				zap.Stringer("policy", c.Publish.Policy),
+			)
 		}
-		defer publication.Close()
 
 		camConfig := app.FliConfig{
-			Width:        uint32(arg.Width),
-			Height:       uint32(arg.Height),
-			OffsetX:      uint16(arg.OffsetX),
-			OffsetY:      uint16(arg.OffsetY),
-			SerialNumber: arg.CameraSerialNumber,
+			Width:                   uint32(arg.Width),
+			Height:                  uint32(arg.Height),
+			OffsetX:                 uint16(arg.OffsetX),
+			OffsetY:                 uint16(arg.OffsetY),
+			SerialNumber:            arg.CameraSerialNumber,
+			QueueLength:             arg.PublishQueue,
+			PublisherCPUs:           cpus,
+			PauseWithoutSubscribers: arg.PublishPause,
 		}
-		cam, err := app.NewFliCamera(camConfig, publication)
+		var backend app.CameraBackend
+		switch arg.Camera {
+		case "fli":
+			if newFliBackend == nil {
+				return errors.New("fli backend not built: rebuild with -tags flisdk")
+			}
+			backend, err = newFliBackend()
+			if err != nil {
+				return errors.Wrap(err, "flisdk")
+			}
+		case "sim":
+			pattern, err := sim.ParsePattern(arg.SimPattern)
+			if err != nil {
+				return err
+			}
+			backend, err = sim.NewBackend(sim.Config{
+				FPS:     arg.SimFPS,
+				Pattern: pattern,
+				Seed:    time.Now().UnixNano(),
+			})
+			if err != nil {
+				return errors.Wrap(err, "sim")
+			}
+		case "replay":
+			pacing, err := replay.ParsePacing(arg.ReplayPacing)
+			if err != nil {
+				return err
+			}
+			// Raw frames are taken to be of the size of the initial
+			// region of interest
+			backend, err = replay.NewBackend(lg, replay.Config{
+				Path:   arg.ReplayPath,
+				Pacing: pacing,
+				FPS:    arg.ReplayFPS,
+				Loop:   arg.ReplayLoop,
+				Width:  arg.Width,
+				Height: arg.Height,
+			})
+			if err != nil {
+				return errors.Wrap(err, "replay")
+			}
+		default:
+			return errors.Errorf("unknown camera backend: %s", arg.Camera)
+		}
+
+		cam, err := app.NewFliCamera(lg, camConfig, backend, outputs)
 		if err != nil {
+			backend.Shutdown()
 			return errors.Wrap(err, "flicamera")
 		}
+		if arg.FrameRate > 0 {
+			fps, err := cam.SetFrameRate(arg.FrameRate)
+			if err != nil {
+				cam.Shutdown()
+				return errors.Wrap(err, "set frame rate")
+			}
+			lg.Info("Frame rate set", zap.Float64("fps", fps.Value))
+		}
+		if arg.IntegrationTime > 0 {
+			tint, err := cam.SetIntegrationTime(arg.IntegrationTime)
+			if err != nil {
+				cam.Shutdown()
+				return errors.Wrap(err, "set integration time")
+			}
+			lg.Info("Integration time set", zap.Float64("tint", tint.Value))
+		}
+
+		if !math.IsNaN(arg.SensorSetpoint) {
+			setpoint, err := cam.SetSensorSetpoint(arg.SensorSetpoint)
+			if err != nil {
+				cam.Shutdown()
+				return errors.Wrap(err, "set sensor setpoint")
+			}
+			lg.Info("Sensor setpoint set", zap.Float64("setpoint", setpoint.Value))
+		}
+
+		// Publications of the lost client are recreated once reconnected
+		transport.OnDisconnect(func() {
+			for _, o := range outputs {
+				o.Disconnect()
+			}
+		})
+		transport.OnConnect(func(*aeron.Aeron) error {
+			for _, o := range outputs {
+				o.Reconnect()
+			}
+			return nil
+		})
+
+		var counters *app.AeronCounters
+		if arg.AeronCounters > 0 {
+			counters = app.NewAeronCounters(lg, cam, arg.AeronCounters)
+			if err := counters.Allocate(a); err != nil {
+				cam.Shutdown()
+				return errors.Wrap(err, "aeron counters")
+			}
+			transport.OnDisconnect(counters.Release)
+			transport.OnConnect(counters.Allocate)
+		}
+
+		var control *app.Control
+		if arg.ControlChannel != "" {
+			control = app.NewControl(lg, cam, app.ControlConfig{
+				Channel:          arg.ControlChannel,
+				StreamID:         int32(arg.ControlStreamId),
+				ResponseChannel:  arg.ControlResponse,
+				ResponseStreamID: int32(arg.ControlResponseId),
+			})
+			if err := control.Allocate(a); err != nil {
+				cam.Shutdown()
+				return errors.Wrap(err, "aeron")
+			}
+			transport.OnDisconnect(control.Release)
+			transport.OnConnect(control.Allocate)
+		}
+
+		var recorder *app.Archive
+		if arg.ArchiveRequest != "" {
+			recorder = app.NewArchive(lg, cam, app.ArchiveConfig{
+				ReplayChannel:  arg.ArchiveReplay,
+				ReplayStreamID: int32(arg.ArchiveReplayId),
+			}, func() (app.ArchiveClient, error) {
+				options := archive.DefaultOptions()
+				options.RequestChannel = arg.ArchiveRequest
+				options.RequestStream = int32(arg.ArchiveRequestId)
+				options.ResponseChannel = arg.ArchiveResponse
+				options.ResponseStream = int32(arg.ArchiveResponseId)
+				ctx := aeron.NewContext().MediaDriverTimeout(arg.AeronDriverTimeout)
+				if arg.AeronDir != "" {
+					ctx.AeronDir(arg.AeronDir)
+				}
+				client, err := archive.NewArchive(options, ctx)
+				if err != nil {
+					return nil, err
+				}
+				return client, nil
+			}, transport.AddSubscription, transport.AddPublication)
+			transport.OnDisconnect(recorder.Release)
+		}
+
+		if err := metrics.RegisterCamera(cam); err != nil {
+			cam.Shutdown()
+			return errors.Wrap(err, "metrics")
+		}
+		if err := metrics.RegisterTransport(transport); err != nil {
+			cam.Shutdown()
+			return errors.Wrap(err, "metrics")
+		}
+
+		thermal := app.NewThermalMonitor(cam, lg, app.ThermalConfig{
+			Interval:  arg.ThermalInterval,
+			Tolerance: arg.ThermalTolerance,
+			Window:    arg.ThermalWindow,
+		})
+
+		oasServer, err := oas.NewServer(api.NewHandler(cam, thermal, transport, recorder, metrics.TracerProvider()),
+			oas.WithTracerProvider(metrics.TracerProvider()),
+			oas.WithMeterProvider(metrics.MeterProvider()),
+		)
+		if err != nil {
+			return errors.Wrap(err, "server init")
+		}
+		httpServer := http.Server{
+			Addr:    arg.Addr,
+			Handler: oasServer,
+		}
 
 		g, ctx := errgroup.WithContext(ctx)
-		// g.Go(func() error {
-		// 	return metrics.Run(ctx)
-		// })
+		g.Go(func() error {
+			return metrics.Run(ctx)
+		})
+		g.Go(func() error {
+			return thermal.Run(ctx)
+		})
+		g.Go(func() error {
+			return transport.Run(ctx)
+		})
+		if counters != nil {
+			g.Go(func() error {
+				return counters.Run(ctx)
+			})
+		}
+		if control != nil {
+			g.Go(func() error {
+				return control.Run(ctx)
+			})
+		}
+		if recorder != nil {
+			g.Go(func() error {
+				return recorder.Run(ctx)
+			})
+		}
 		g.Go(func() error {
 			if err := cam.StartCamera(); err != nil {
 				return errors.Wrap(err, "flicamera")
 			}
 			return cam.Run(ctx)
 		})
-		// g.Go(func() error {
-		// 	<-ctx.Done()
-		// 	if err := httpServer.Shutdown(ctx); err != nil {
-		// 		return errors.Wrap(err, "http")
-		// 	}
-		// 	return nil
-		// })
-		// g.Go(func() error {
-		// 	defer lg.Info("HTTP server stopped")
-		// 	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		// 		return errors.Wrap(err, "http")
-		// 	}
-		// 	return nil
-		// })
+		g.Go(func() error {
+			<-ctx.Done()
+			if err := httpServer.Shutdown(context.Background()); err != nil {
+				return errors.Wrap(err, "http")
+			}
+			return nil
+		})
+		g.Go(func() error {
+			defer lg.Info("HTTP server stopped")
+			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				return errors.Wrap(err, "http")
+			}
+			return nil
+		})
 
 		return g.Wait()
 	})
 }
+
+// parseCPUs parses a comma separated list of CPU numbers.
+func parseCPUs(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var cpus []int
+	for _, f := range strings.Split(s, ",") {
+		cpu, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || cpu < 0 {
+			return nil, errors.Errorf("invalid CPU: %q", f)
+		}
+		cpus = append(cpus, cpu)
+	}
+	return cpus, nil
+}
+
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
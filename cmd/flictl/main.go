@@ -0,0 +1,473 @@
+// Command flictl controls a running FLI camera service through its HTTP
+// API.
+//
+//	flictl [flags] status
+//	flictl [flags] start|stop
+//	flictl [flags] set exposure|fps|temp <value>
+//	flictl [flags] set roi <width> <height> [<offsetX> <offsetY>]
+//	flictl [flags] snapshot [snapshot flags]
+//	flictl [flags] record start [<output>]|stop <id>|list
+//	flictl [flags] record replay <id> <channel> <streamId> [<rate>]
+//	flictl [flags] record replays|cancel <replayId>
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/oas"
+)
+
+// errUsage is returned for invalid command lines, after printing the usage.
+var errUsage = errors.New("usage")
+
+// cli holds the client and output settings of a command.
+type cli struct {
+	client *oas.Client
+	json   bool
+	out    io.Writer
+}
+
+func main() {
+	var arg struct {
+		Server  string
+		JSON    bool
+		Timeout time.Duration
+	}
+	flag.StringVar(&arg.Server, "server", "http://127.0.0.1:8080", "URL of the camera service API")
+	flag.BoolVar(&arg.JSON, "json", false, "Print JSON instead of tables")
+	flag.DurationVar(&arg.Timeout, "timeout", 10*time.Second, "Timeout of each API request")
+	flag.Usage = usage
+	flag.Parse()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	client, err := oas.NewClient(arg.Server, oas.WithClient(&http.Client{Timeout: arg.Timeout}))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "flictl:", err)
+		os.Exit(1)
+	}
+	c := &cli{client: client, json: arg.JSON, out: os.Stdout}
+	if err := c.run(ctx, flag.Args()); err != nil {
+		if errors.Is(err, errUsage) {
+			usage()
+			os.Exit(2)
+		}
+		var se *oas.ErrorStatusCode
+		if errors.As(err, &se) {
+			err = fmt.Errorf("%s (%d %s)", se.Response.Message, se.StatusCode, http.StatusText(se.StatusCode))
+		}
+		fmt.Fprintln(os.Stderr, "flictl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprint(flag.CommandLine.Output(), `Usage: flictl [flags] <command>
+
+Commands:
+  status                                  camera, acquisition, exposure, temperature and pipeline status
+  start, stop                             start or stop acquisition
+  set exposure <seconds>                  set the integration time
+  set fps <hz>                            set the frame rate
+  set roi <w> <h> [<offsetX> <offsetY>]   set the region of interest
+  set temp <celsius>                      set the sensor temperature setpoint
+  snapshot [-h for flags]                 save a frame, or the mean of frames, as FITS, PNG or raw
+  record start [<output>]                 start recording an output, the first one by default
+  record stop <id>                        stop a recording
+  record list                             list the recordings
+  record replay <id> <channel> <streamId> [<rate>]
+                                          replay a recording, rate 0 as fast as possible
+  record replays                          list the replays
+  record cancel <replayId>                stop a replay
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "status":
+		return c.status(ctx)
+	case "start":
+		s, err := c.client.StartAcquisition(ctx)
+		if err != nil {
+			return err
+		}
+		return c.print(s, acquisitionRows(s))
+	case "stop":
+		s, err := c.client.StopAcquisition(ctx)
+		if err != nil {
+			return err
+		}
+		return c.print(s, acquisitionRows(s))
+	case "set":
+		return c.set(ctx, args)
+	case "snapshot":
+		return c.snapshot(ctx, args)
+	case "record":
+		return c.record(ctx, args)
+	}
+	return errUsage
+}
+
+func (c *cli) set(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return errUsage
+	}
+	if args[0] == "roi" {
+		if len(args) != 3 && len(args) != 5 {
+			return errUsage
+		}
+		v, err := parseInts(args[1:])
+		if err != nil {
+			return err
+		}
+		roi := &oas.ROI{Width: v[0], Height: v[1]}
+		if len(v) == 4 {
+			roi.OffsetX, roi.OffsetY = v[2], v[3]
+		}
+		g, err := c.client.SetROI(ctx, roi)
+		if err != nil {
+			return err
+		}
+		return c.print(g, geometryRows(g))
+	}
+
+	if len(args) != 2 {
+		return errUsage
+	}
+	value, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid value %q", args[1])
+	}
+	var (
+		set  func(context.Context, *oas.SetValue) (*oas.LimitedValue, error)
+		name string
+	)
+	switch args[0] {
+	case "exposure":
+		set, name = c.client.SetIntegrationTime, "exposure (s)"
+	case "fps":
+		set, name = c.client.SetFrameRate, "frame rate (Hz)"
+	case "temp":
+		set, name = c.client.SetSensorSetpoint, "setpoint (°C)"
+	default:
+		return errUsage
+	}
+	v, err := set(ctx, &oas.SetValue{Value: value})
+	if err != nil {
+		return err
+	}
+	return c.print(v, limitedRows(name, v))
+}
+
+func (c *cli) record(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch cmd, args := args[0], args[1:]; cmd {
+	case "start":
+		if len(args) > 1 {
+			return errUsage
+		}
+		req := &oas.StartRecording{}
+		if len(args) == 1 {
+			req.Output = oas.NewOptString(args[0])
+		}
+		r, err := c.client.StartRecording(ctx, req)
+		if err != nil {
+			return err
+		}
+		return c.printTable(r, recordingTable(*r))
+	case "stop":
+		id, err := parseID(args)
+		if err != nil {
+			return err
+		}
+		r, err := c.client.StopRecording(ctx, oas.StopRecordingParams{RecordingId: id})
+		if err != nil {
+			return err
+		}
+		return c.printTable(r, recordingTable(*r))
+	case "list":
+		if len(args) != 0 {
+			return errUsage
+		}
+		rs, err := c.client.ListRecordings(ctx)
+		if err != nil {
+			return err
+		}
+		return c.printTable(rs, recordingTable(rs...))
+	case "replay":
+		if len(args) != 3 && len(args) != 4 {
+			return errUsage
+		}
+		id, err := parseID(args[:1])
+		if err != nil {
+			return err
+		}
+		stream, err := strconv.ParseInt(args[2], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid stream id %q", args[2])
+		}
+		req := &oas.ReplayRequest{Channel: args[1], StreamId: int32(stream)}
+		if len(args) == 4 {
+			rate, err := strconv.ParseFloat(args[3], 64)
+			if err != nil {
+				return fmt.Errorf("invalid rate %q", args[3])
+			}
+			req.Rate = oas.NewOptFloat64(rate)
+		}
+		r, err := c.client.StartReplay(ctx, req, oas.StartReplayParams{RecordingId: id})
+		if err != nil {
+			return err
+		}
+		return c.printTable(r, replayTable(*r))
+	case "replays":
+		if len(args) != 0 {
+			return errUsage
+		}
+		rs, err := c.client.ListReplays(ctx)
+		if err != nil {
+			return err
+		}
+		return c.printTable(rs, replayTable(rs...))
+	case "cancel":
+		id, err := parseID(args)
+		if err != nil {
+			return err
+		}
+		return c.client.StopReplay(ctx, oas.StopReplayParams{ReplayId: id})
+	}
+	return errUsage
+}
+
+// status prints the state of the service. Parts the camera does not
+// support are left out.
+func (c *cli) status(ctx context.Context) error {
+	camera, err := c.client.GetCamera(ctx)
+	if err != nil {
+		return err
+	}
+	status := struct {
+		Camera          *oas.CameraInfo       `json:"camera"`
+		Acquisition     *oas.AcquisitionState `json:"acquisition,omitempty"`
+		Geometry        *oas.Geometry         `json:"geometry,omitempty"`
+		IntegrationTime *oas.LimitedValue     `json:"integrationTime,omitempty"`
+		FrameRate       *oas.LimitedValue     `json:"frameRate,omitempty"`
+		Temperature     *oas.ThermalStatus    `json:"temperature,omitempty"`
+		Transport       *oas.TransportStatus  `json:"transport,omitempty"`
+		Stats           *oas.PipelineStats    `json:"stats,omitempty"`
+	}{Camera: camera}
+	var errs []error
+	get := func(f func() error) {
+		if err := f(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	get(func() (err error) { status.Acquisition, err = c.client.GetAcquisition(ctx); return })
+	get(func() (err error) { status.Geometry, err = c.client.GetGeometry(ctx); return })
+	get(func() (err error) { status.IntegrationTime, err = c.client.GetIntegrationTime(ctx); return })
+	get(func() (err error) { status.FrameRate, err = c.client.GetFrameRate(ctx); return })
+	get(func() (err error) { status.Temperature, err = c.client.GetTemperature(ctx); return })
+	get(func() (err error) { status.Transport, err = c.client.GetTransport(ctx); return })
+	get(func() (err error) { status.Stats, err = c.client.GetStats(ctx); return })
+	for _, err := range errs {
+		var se *oas.ErrorStatusCode
+		if !errors.As(err, &se) || se.StatusCode != http.StatusNotImplemented {
+			return err
+		}
+	}
+	if c.json {
+		return c.writeJSON(status)
+	}
+
+	rows := [][]string{
+		{"camera", camera.Model},
+		{"serial", camera.SerialNumber},
+	}
+	if s := status.Acquisition; s != nil {
+		rows = append(rows, acquisitionRows(s)...)
+	}
+	if g := status.Geometry; g != nil {
+		rows = append(rows, geometryRows(g)...)
+	}
+	if v := status.IntegrationTime; v != nil {
+		rows = append(rows, limitedRows("exposure (s)", v)...)
+	}
+	if v := status.FrameRate; v != nil {
+		rows = append(rows, limitedRows("frame rate (Hz)", v)...)
+	}
+	if t := status.Temperature; t != nil {
+		rows = append(rows,
+			[]string{"sensor (°C)", fmt.Sprintf("%.2f (setpoint %.2f, stable %t)", t.Sensor, t.Setpoint, t.Stable)},
+			[]string{"TEC power (%)", fmt.Sprintf("%.1f", t.TecPower)},
+		)
+	}
+	if t := status.Transport; t != nil {
+		state := string(t.State)
+		if e, ok := t.LastError.Get(); ok {
+			state += ", last error: " + e
+		}
+		rows = append(rows, []string{"aeron", fmt.Sprintf("%s since %s (%d reconnects)",
+			state, t.Since.Format(time.RFC3339), t.Reconnects)})
+	}
+	if s := status.Stats; s != nil {
+		rows = append(rows,
+			[]string{"measured fps", fmt.Sprintf("%.1f", s.FrameRate)},
+			[]string{"frames", fmt.Sprintf("%d received, %d grabber dropped, %d queue overruns, queue %d/%d",
+				s.FramesReceived, s.GrabberDropped, s.QueueOverruns, s.QueueDepth, s.QueueCapacity)},
+		)
+	}
+	if err := c.writeTable(nil, rows); err != nil {
+		return err
+	}
+	if s := status.Stats; s != nil && len(s.Outputs) > 0 {
+		fmt.Fprintln(c.out)
+		rows := make([][]string, len(s.Outputs))
+		for i, o := range s.Outputs {
+			rows[i] = []string{o.Name, o.Channel, strconv.Itoa(int(o.StreamId)), strconv.Itoa(int(o.Decimation)),
+				string(o.State), string(o.Policy.Policy), fmt.Sprint(o.FramesPublished),
+				fmt.Sprint(o.PublicationDropped), fmt.Sprint(o.NotConnected)}
+		}
+		return c.writeTable([]string{"OUTPUT", "CHANNEL", "STREAM", "DECIMATION", "STATE", "POLICY",
+			"PUBLISHED", "DROPPED", "NOT CONNECTED"}, rows)
+	}
+	return nil
+}
+
+func acquisitionRows(s *oas.AcquisitionState) [][]string {
+	state := "stopped"
+	switch {
+	case s.Paused:
+		state = "paused, no subscribers"
+	case s.Acquiring:
+		state = "acquiring"
+	}
+	return [][]string{{"acquisition", state}}
+}
+
+func geometryRows(g *oas.Geometry) [][]string {
+	return [][]string{
+		{"roi", fmt.Sprintf("%dx%d+%d+%d", g.Width, g.Height, g.OffsetX, g.OffsetY)},
+		{"image size (bytes)", strconv.Itoa(int(g.ImageSizeBytes))},
+	}
+}
+
+func limitedRows(name string, v *oas.LimitedValue) [][]string {
+	return [][]string{{name, fmt.Sprintf("%g [%g, %g]", v.Value, v.Min, v.Max)}}
+}
+
+// table is a header and its rows.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func recordingTable(rs ...oas.Recording) table {
+	t := table{header: []string{"ID", "CHANNEL", "STREAM", "SESSION", "ACTIVE", "START", "STOP", "FRAMES", "BYTES"}}
+	for _, r := range rs {
+		stop := "-"
+		if s, ok := r.StopTime.Get(); ok {
+			stop = s.Format(time.RFC3339)
+		}
+		t.rows = append(t.rows, []string{fmt.Sprint(r.ID), r.Channel, fmt.Sprint(r.StreamId),
+			fmt.Sprint(r.SessionId), strconv.FormatBool(r.Active), r.StartTime.Format(time.RFC3339), stop,
+			fmt.Sprint(r.Frames), fmt.Sprint(r.StopPosition - r.StartPosition)})
+	}
+	return t
+}
+
+func replayTable(rs ...oas.Replay) table {
+	t := table{header: []string{"ID", "RECORDING", "CHANNEL", "STREAM", "RATE", "FRAMES"}}
+	for _, r := range rs {
+		t.rows = append(t.rows, []string{fmt.Sprint(r.ID), fmt.Sprint(r.RecordingId), r.Channel,
+			fmt.Sprint(r.StreamId), fmt.Sprint(r.Rate), fmt.Sprint(r.Frames)})
+	}
+	return t
+}
+
+// print prints v as JSON or rows as a table of names and values.
+func (c *cli) print(v any, rows [][]string) error {
+	if c.json {
+		return c.writeJSON(v)
+	}
+	return c.writeTable(nil, rows)
+}
+
+// printTable prints v as JSON or t.
+func (c *cli) printTable(v any, t table) error {
+	if c.json {
+		return c.writeJSON(v)
+	}
+	return c.writeTable(t.header, t.rows)
+}
+
+func (c *cli) writeJSON(v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.out, "%s\n", b)
+	return err
+}
+
+func (c *cli) writeTable(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	line := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprint(w, cell)
+		}
+		fmt.Fprintln(w)
+	}
+	if header != nil {
+		line(header)
+	}
+	for _, row := range rows {
+		line(row)
+	}
+	return w.Flush()
+}
+
+func parseInts(args []string) ([]int32, error) {
+	v := make([]int32, len(args))
+	for i, a := range args {
+		n, err := strconv.ParseInt(a, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", a)
+		}
+		v[i] = int32(n)
+	}
+	return v, nil
+}
+
+func parseID(args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, errUsage
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", args[0])
+	}
+	return id, nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/oas"
+)
+
+// snapshot saves the next frame, or the mean of the next frames, as FITS,
+// 16-bit PNG or raw little-endian pixels.
+func (c *cli) snapshot(ctx context.Context, args []string) error {
+	var arg struct {
+		Output string
+		Format string
+		Frames int
+	}
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	fs.StringVar(&arg.Output, "o", "snapshot.fits", "Output file")
+	fs.StringVar(&arg.Format, "format", "", "fits, png or raw, from the output file extension if empty")
+	fs.IntVar(&arg.Frames, "frames", 1, "Number of consecutive frames to average")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() != 0 {
+		return errUsage
+	}
+	format := arg.Format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(arg.Output)), ".")
+		if format == "fit" || format == "fts" {
+			format = "fits"
+		}
+	}
+	var snapshotFormat oas.SnapshotFormat
+	if err := snapshotFormat.UnmarshalText([]byte(format)); err != nil {
+		return fmt.Errorf("unknown snapshot format %q", format)
+	}
+
+	res, err := c.client.GetSnapshot(ctx, oas.GetSnapshotParams{
+		Format: oas.NewOptSnapshotFormat(snapshotFormat),
+		Frames: oas.NewOptInt32(int32(arg.Frames)),
+	})
+	if err != nil {
+		return err
+	}
+	var (
+		h    snapshotHeaders
+		body io.Reader
+	)
+	switch res := res.(type) {
+	case *oas.GetSnapshotOKApplicationFitsHeaders:
+		h, body = res, res.Response
+	case *oas.GetSnapshotOKImagePNGHeaders:
+		h, body = res, res.Response
+	case *oas.GetSnapshotOKApplicationOctetStreamHeaders:
+		h, body = res, res.Response
+	default:
+		return fmt.Errorf("unexpected snapshot response %T", res)
+	}
+
+	f, err := os.Create(arg.Output)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return c.print(map[string]any{
+		"file":           arg.Output,
+		"format":         format,
+		"frames":         h.GetXFrames(),
+		"sequenceNumber": h.GetXSequenceNumber(),
+		"timestamp":      h.GetXTimestamp(),
+		"width":          h.GetXWidth(),
+		"height":         h.GetXHeight(),
+		"offsetX":        h.GetXOffsetX(),
+		"offsetY":        h.GetXOffsetY(),
+		"pixelFormat":    h.GetXPixelFormat(),
+	}, [][]string{
+		{"file", arg.Output},
+		{"frames", fmt.Sprint(h.GetXFrames())},
+		{"sequence", fmt.Sprint(h.GetXSequenceNumber())},
+		{"timestamp", h.GetXTimestamp().Format(time.RFC3339Nano)},
+		{"roi", fmt.Sprintf("%dx%d+%d+%d", h.GetXWidth(), h.GetXHeight(), h.GetXOffsetX(), h.GetXOffsetY())},
+		{"format", h.GetXPixelFormat()},
+	})
+}
+
+// snapshotHeaders are the response headers common to the snapshot formats.
+type snapshotHeaders interface {
+	GetXWidth() int32
+	GetXHeight() int32
+	GetXOffsetX() int32
+	GetXOffsetY() int32
+	GetXPixelFormat() string
+	GetXFrames() int32
+	GetXSequenceNumber() int64
+	GetXTimestamp() time.Time
+}
@@ -0,0 +1,267 @@
+// Command flistat attaches to a camera frame stream and prints a summary
+// of it at every interval: frame rate and period jitter, geometry and
+// format, dropped sequence numbers, latency and the pixel statistics of a
+// sample frame.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/idlestrategy"
+	"go.uber.org/zap"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+	"github.com/New-Earth-Lab/flicameraservice/pkg/framestream"
+)
+
+// maxDropRanges bounds the dropped sequence ranges printed per interval.
+const maxDropRanges = 8
+
+func main() {
+	app.Run(func(ctx context.Context, lg *zap.Logger) error {
+		var arg struct {
+			AeronUri           string
+			AeronStreamId      int
+			AeronDir           string
+			AeronDriverTimeout time.Duration
+			Decimation         int
+			Interval           time.Duration
+		}
+		flag.StringVar(&arg.AeronUri, "aeron.Uri", "aeron:ipc", "Aeron channel URI of the frames")
+		flag.IntVar(&arg.AeronStreamId, "aeron.StreamId", 1001, "Aeron stream ID of the frames")
+		flag.StringVar(&arg.AeronDir, "aeron.dir", "", "Aeron media driver directory, empty for the default")
+		flag.DurationVar(&arg.AeronDriverTimeout, "aeron.driverTimeout", app.DefaultDriverTimeout, "Time without a media driver heartbeat after which the client fails")
+		flag.IntVar(&arg.Decimation, "decimation", 1, "Decimation factor of the output, so that the frames it skips are not reported as dropped")
+		flag.DurationVar(&arg.Interval, "interval", time.Second, "Summary interval")
+		flag.Parse()
+
+		if arg.Interval <= 0 {
+			return errors.New("interval must be positive")
+		}
+
+		actx := aeron.NewContext().MediaDriverTimeout(arg.AeronDriverTimeout)
+		if arg.AeronDir != "" {
+			actx.AeronDir(arg.AeronDir)
+		}
+		client, err := aeron.Connect(actx)
+		if err != nil {
+			return errors.Wrap(err, "aeron connect")
+		}
+		defer client.Close()
+
+		s := newSummary(arg.Decimation)
+		sub, err := framestream.Subscribe(client, framestream.Config{
+			Channel:    arg.AeronUri,
+			StreamID:   int32(arg.AeronStreamId),
+			Decimation: arg.Decimation,
+			OnInvalid:  s.onInvalid,
+		}, s.onFrame)
+		if err != nil {
+			return err
+		}
+		defer sub.Close()
+
+		idle := idlestrategy.NewDefaultBackoffIdleStrategy()
+		next := time.Now().Add(arg.Interval)
+		for ctx.Err() == nil {
+			idle.Idle(sub.Poll())
+			if now := time.Now(); !now.Before(next) {
+				s.print(os.Stdout, now, sub.IsConnected())
+				next = next.Add(arg.Interval)
+				if next.Before(now) {
+					next = now.Add(arg.Interval)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// summary accumulates the frames of an interval.
+type summary struct {
+	decimation int64
+	start      time.Time
+
+	frames   int
+	invalid  int
+	lastErr  error
+	gaps     int
+	last     framestream.Frame
+	haveLast bool
+
+	// Frame periods from TimestampNs
+	periods       int
+	periodSum     float64
+	periodSquares float64
+	periodMax     time.Duration
+
+	latencyMin, latencyMax, latencySum time.Duration
+
+	dropped    int64
+	dropRanges []string
+
+	// Pixel statistics of the first frame of the interval
+	sampled        bool
+	sample         int64
+	pixMin, pixMax uint16
+	pixMean        float64
+
+	geometryChanges int
+	restarts        int
+}
+
+func newSummary(decimation int) *summary {
+	if decimation <= 0 {
+		decimation = 1
+	}
+	return &summary{decimation: int64(decimation), start: time.Now()}
+}
+
+func (s *summary) onInvalid(err error) {
+	s.invalid++
+	s.lastErr = err
+}
+
+func (s *summary) onFrame(f *framestream.Frame) {
+	s.frames++
+	if s.frames == 1 || f.Latency < s.latencyMin {
+		s.latencyMin = f.Latency
+	}
+	if s.frames == 1 || f.Latency > s.latencyMax {
+		s.latencyMax = f.Latency
+	}
+	s.latencySum += f.Latency
+
+	if s.haveLast {
+		if f.SequenceNumber <= s.last.SequenceNumber {
+			s.restarts++
+		} else if f.Dropped == 0 {
+			period := time.Duration(f.TimestampNs - s.last.TimestampNs)
+			p := float64(period)
+			s.periods++
+			s.periodSum += p
+			s.periodSquares += p * p
+			if period > s.periodMax {
+				s.periodMax = period
+			}
+		}
+		if f.Width != s.last.Width || f.Height != s.last.Height ||
+			f.OffsetX != s.last.OffsetX || f.OffsetY != s.last.OffsetY {
+			s.geometryChanges++
+		}
+	}
+	if f.Dropped > 0 {
+		s.gaps++
+		s.dropped += f.Dropped
+		if len(s.dropRanges) < maxDropRanges {
+			first := f.SequenceNumber - f.Dropped*s.decimation
+			last := f.SequenceNumber - s.decimation
+			if first == last {
+				s.dropRanges = append(s.dropRanges, fmt.Sprint(first))
+			} else {
+				s.dropRanges = append(s.dropRanges, fmt.Sprintf("%d-%d", first, last))
+			}
+		}
+	}
+
+	if !s.sampled {
+		s.sampled = true
+		s.sample = f.SequenceNumber
+		s.pixMin, s.pixMax, s.pixMean = pixelStats(f)
+	}
+
+	// Keep the fixed fields only, the slices alias the receive buffer
+	s.last = *f
+	s.last.Metadata, s.last.Pixels = nil, nil
+	s.haveLast = true
+}
+
+// pixelStats returns the minimum, maximum and mean of the pixels of f.
+func pixelStats(f *framestream.Frame) (lo, hi uint16, mean float64) {
+	if f.Width == 0 || f.Height == 0 {
+		return 0, 0, math.NaN()
+	}
+	lo = math.MaxUint16
+	sum := uint64(0)
+	stride := f.Stride()
+	for y := 0; y < f.Height; y++ {
+		row := f.Pixels[y*stride : y*stride+2*f.Width]
+		for i := 0; i < len(row); i += 2 {
+			v := uint16(row[i]) | uint16(row[i+1])<<8
+			if v < lo {
+				lo = v
+			}
+			if v > hi {
+				hi = v
+			}
+			sum += uint64(v)
+		}
+	}
+	return lo, hi, float64(sum) / float64(f.Width*f.Height)
+}
+
+// print writes the summary of the interval ending at now and starts the
+// next one.
+func (s *summary) print(w io.Writer, now time.Time, connected bool) {
+	var b strings.Builder
+	b.WriteString(now.Format("15:04:05"))
+	elapsed := now.Sub(s.start).Seconds()
+	switch {
+	case s.frames == 0 && !connected:
+		b.WriteString(" not connected")
+	case s.frames == 0:
+		b.WriteString(" no frames")
+	default:
+		f := &s.last
+		fmt.Fprintf(&b, " seq %d fps %.1f", f.SequenceNumber, float64(s.frames)/elapsed)
+		if s.periods > 0 {
+			mean := s.periodSum / float64(s.periods)
+			jitter := math.Sqrt(math.Max(s.periodSquares/float64(s.periods)-mean*mean, 0))
+			fmt.Fprintf(&b, " period %v jitter %v max %v",
+				time.Duration(mean).Round(time.Microsecond/10),
+				time.Duration(jitter).Round(time.Microsecond/10),
+				s.periodMax.Round(time.Microsecond/10))
+		}
+		fmt.Fprintf(&b, " | %dx%d+%d+%d %s", f.Width, f.Height, f.OffsetX, f.OffsetY,
+			strings.TrimPrefix(f.Format.String(), "PixelFormat"))
+		if s.geometryChanges > 0 {
+			fmt.Fprintf(&b, " (%d changes)", s.geometryChanges)
+		}
+		fmt.Fprintf(&b, " | latency %v/%v/%v",
+			s.latencyMin.Round(time.Microsecond),
+			(s.latencySum / time.Duration(s.frames)).Round(time.Microsecond),
+			s.latencyMax.Round(time.Microsecond))
+		fmt.Fprintf(&b, " | pixels %d/%.1f/%d (seq %d)", s.pixMin, s.pixMean, s.pixMax, s.sample)
+	}
+	fmt.Fprintf(&b, " | dropped %d", s.dropped)
+	if len(s.dropRanges) > 0 {
+		fmt.Fprintf(&b, " [%s", strings.Join(s.dropRanges, " "))
+		if s.gaps > len(s.dropRanges) {
+			b.WriteString(" ...")
+		}
+		b.WriteString("]")
+	}
+	if s.restarts > 0 {
+		fmt.Fprintf(&b, " | restarts %d", s.restarts)
+	}
+	if s.invalid > 0 {
+		fmt.Fprintf(&b, " | invalid %d: %v", s.invalid, s.lastErr)
+	}
+	fmt.Fprintln(w, b.String())
+
+	*s = summary{
+		decimation: s.decimation,
+		start:      now,
+		last:       s.last,
+		haveLast:   s.haveLast,
+	}
+}
@@ -1,3 +1,4 @@
 package example
 
 //go:generate go run github.com/ogen-go/ogen/cmd/ogen --clean --package oas --target internal/oas _oas/openapi.yml
+//go:generate go run ./internal/tools/sbegen -o pkg/framecodec/frame_gen.go _sbe/frame.xml
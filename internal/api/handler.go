@@ -1,9 +1,22 @@
 package api
 
 import (
+	"bytes"
 	"context"
-	"fmt"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/png"
+	"math"
+	"net/http"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+	"github.com/New-Earth-Lab/flicameraservice/internal/fits"
 	"github.com/New-Earth-Lab/flicameraservice/internal/oas"
 )
 
@@ -12,12 +25,478 @@ var _ oas.Handler = (*Handler)(nil)
 
 type Handler struct {
 	oas.UnimplementedHandler // automatically implement all methods
+
+	cam       *app.FLICamera
+	thermal   *app.ThermalMonitor
+	transport *app.Transport
+	archive   *app.Archive
+	tracer    trace.Tracer
 }
 
-func (h Handler) GetPetById(ctx context.Context, params oas.GetPetByIdParams) (oas.GetPetByIdRes, error) {
-	return &oas.Pet{
-		ID:     oas.NewOptInt64(params.PetId),
-		Name:   fmt.Sprintf("Pet %d", params.PetId),
-		Status: oas.NewOptPetStatus(oas.PetStatusAvailable),
+// NewHandler returns the API handler. archive is nil when the service runs
+// without an Aeron Archive.
+func NewHandler(cam *app.FLICamera, thermal *app.ThermalMonitor, transport *app.Transport,
+	archive *app.Archive, tp trace.TracerProvider) *Handler {
+	return &Handler{
+		cam:       cam,
+		thermal:   thermal,
+		transport: transport,
+		archive:   archive,
+		tracer:    tp.Tracer("github.com/New-Earth-Lab/flicameraservice/internal/api"),
+	}
+}
+
+func (h *Handler) GetCamera(ctx context.Context) (*oas.CameraInfo, error) {
+	return &oas.CameraInfo{
+		SerialNumber: h.cam.SerialNumber(),
+		Model:        h.cam.Model(),
 	}, nil
 }
+
+func (h *Handler) GetGeometry(ctx context.Context) (*oas.Geometry, error) {
+	return geometry(h.cam.Geometry()), nil
+}
+
+func (h *Handler) SetROI(ctx context.Context, req *oas.ROI) (*oas.Geometry, error) {
+	var g app.Geometry
+	err := h.control(ctx, "SetROI", func(context.Context) (err error) {
+		g, err = h.cam.SetROI(uint32(req.Width), uint32(req.Height),
+			uint16(req.OffsetX), uint16(req.OffsetY))
+		return err
+	},
+		attribute.Int("camera.roi.width", int(req.Width)),
+		attribute.Int("camera.roi.height", int(req.Height)),
+		attribute.Int("camera.roi.offset_x", int(req.OffsetX)),
+		attribute.Int("camera.roi.offset_y", int(req.OffsetY)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return geometry(g), nil
+}
+
+func (h *Handler) GetAcquisition(ctx context.Context) (*oas.AcquisitionState, error) {
+	return h.acquisitionState(), nil
+}
+
+func (h *Handler) StartAcquisition(ctx context.Context) (*oas.AcquisitionState, error) {
+	if err := h.control(ctx, "StartAcquisition", func(context.Context) error {
+		return h.cam.StartCamera()
+	}); err != nil {
+		return nil, err
+	}
+	return h.acquisitionState(), nil
+}
+
+func (h *Handler) StopAcquisition(ctx context.Context) (*oas.AcquisitionState, error) {
+	if err := h.control(ctx, "StopAcquisition", func(context.Context) error {
+		return h.cam.StopCamera()
+	}); err != nil {
+		return nil, err
+	}
+	return h.acquisitionState(), nil
+}
+
+func (h *Handler) GetFrameRate(ctx context.Context) (*oas.LimitedValue, error) {
+	return limitedValue(h.cam.FrameRate())
+}
+
+func (h *Handler) SetFrameRate(ctx context.Context, req *oas.SetValue) (*oas.LimitedValue, error) {
+	return h.setValue(ctx, "SetFrameRate", "camera.frame_rate", h.cam.SetFrameRate, req.Value)
+}
+
+func (h *Handler) GetIntegrationTime(ctx context.Context) (*oas.LimitedValue, error) {
+	return limitedValue(h.cam.IntegrationTime())
+}
+
+func (h *Handler) SetIntegrationTime(ctx context.Context, req *oas.SetValue) (*oas.LimitedValue, error) {
+	return h.setValue(ctx, "SetIntegrationTime", "camera.integration_time", h.cam.SetIntegrationTime, req.Value)
+}
+
+func (h *Handler) GetTemperature(ctx context.Context) (*oas.ThermalStatus, error) {
+	s, err := h.thermal.Sample()
+	if err != nil {
+		return nil, err
+	}
+	return &oas.ThermalStatus{
+		Sensor:      s.Sensor,
+		Motherboard: s.Motherboard,
+		Peltier:     s.Peltier,
+		Heatsink:    s.Heatsink,
+		TecPower:    s.TECPower,
+		Setpoint:    s.Setpoint,
+		Stable:      s.Stable,
+		Time:        s.Time,
+	}, nil
+}
+
+func (h *Handler) GetSensorSetpoint(ctx context.Context) (*oas.LimitedValue, error) {
+	return limitedValue(h.cam.SensorSetpoint())
+}
+
+func (h *Handler) SetSensorSetpoint(ctx context.Context, req *oas.SetValue) (*oas.LimitedValue, error) {
+	return h.setValue(ctx, "SetSensorSetpoint", "camera.sensor_setpoint", h.cam.SetSensorSetpoint, req.Value)
+}
+
+func (h *Handler) GetStats(ctx context.Context) (*oas.PipelineStats, error) {
+	s := h.cam.Stats()
+	outputs := make([]oas.OutputStats, len(s.Outputs))
+	for i, o := range s.Outputs {
+		outputs[i] = outputStats(o)
+	}
+	return &oas.PipelineStats{
+		FramesReceived: int64(s.FramesReceived),
+		GrabberDropped: int64(s.GrabberDropped),
+		QueueOverruns:  int64(s.QueueOverruns),
+		QueueDepth:     int32(h.cam.QueueDepth()),
+		QueueCapacity:  int32(h.cam.QueueCapacity()),
+		SequenceNumber: s.SequenceNumber,
+		FrameRate:      s.FrameRate,
+		Outputs:        outputs,
+	}, nil
+}
+
+func (h *Handler) GetSnapshot(ctx context.Context, params oas.GetSnapshotParams) (oas.GetSnapshotRes, error) {
+	format := params.Format.Or(oas.SnapshotFormatFits)
+	frames := int(params.Frames.Or(1))
+	var s *app.Snapshot
+	err := h.control(ctx, "GetSnapshot", func(ctx context.Context) (err error) {
+		s, err = h.cam.Snapshot(ctx, frames)
+		return err
+	},
+		attribute.String("snapshot.format", string(format)),
+		attribute.Int("snapshot.frames", frames),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	width, height := int(s.Geometry.Width), int(s.Geometry.Height)
+	switch format {
+	case oas.SnapshotFormatPNG:
+		img := image.NewGray16(image.Rect(0, 0, width, height))
+		for i, v := range s.Pixels {
+			binary.BigEndian.PutUint16(img.Pix[2*i:], v)
+		}
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		res := &oas.GetSnapshotOKImagePNGHeaders{Response: oas.GetSnapshotOKImagePNG{Data: &buf}}
+		setSnapshotHeaders(res, s)
+		return res, nil
+	case oas.SnapshotFormatRaw:
+		buf.Grow(2 * len(s.Pixels))
+		for _, v := range s.Pixels {
+			buf.Write([]byte{byte(v), byte(v >> 8)})
+		}
+		res := &oas.GetSnapshotOKApplicationOctetStreamHeaders{
+			Response: oas.GetSnapshotOKApplicationOctetStream{Data: &buf},
+		}
+		setSnapshotHeaders(res, s)
+		return res, nil
+	default:
+		hdr := snapshotHeader(s)
+		if s.Mean != nil {
+			err = fits.WriteFloat32(&buf, hdr, width, height, s.Mean)
+		} else {
+			err = fits.WriteUint16(&buf, hdr, width, height, s.Pixels)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res := &oas.GetSnapshotOKApplicationFitsHeaders{Response: oas.GetSnapshotOKApplicationFits{Data: &buf}}
+		setSnapshotHeaders(res, s)
+		return res, nil
+	}
+}
+
+// snapshotHeaders are the response headers common to the snapshot formats.
+type snapshotHeaders interface {
+	SetXWidth(int32)
+	SetXHeight(int32)
+	SetXOffsetX(int32)
+	SetXOffsetY(int32)
+	SetXPixelFormat(string)
+	SetXFrames(int32)
+	SetXSequenceNumber(int64)
+	SetXTimestamp(time.Time)
+}
+
+func setSnapshotHeaders(h snapshotHeaders, s *app.Snapshot) {
+	h.SetXWidth(s.Geometry.Width)
+	h.SetXHeight(s.Geometry.Height)
+	h.SetXOffsetX(s.Geometry.OffsetX)
+	h.SetXOffsetY(s.Geometry.OffsetY)
+	h.SetXPixelFormat("Mono16")
+	h.SetXFrames(int32(s.Frames))
+	h.SetXSequenceNumber(s.SequenceNumber)
+	h.SetXTimestamp(s.End)
+}
+
+// snapshotHeader returns the FITS keywords describing a snapshot. Unknown
+// exposure settings and temperatures are left out.
+func snapshotHeader(s *app.Snapshot) *fits.Header {
+	const dateFormat = "2006-01-02T15:04:05.000000"
+	var h fits.Header
+	h.Set("DATE-OBS", s.Start.UTC().Format(dateFormat), "UTC time the first frame was received")
+	h.Set("DATE-END", s.End.UTC().Format(dateFormat), "UTC time the last frame was received")
+	h.Set("INSTRUME", s.Model, "camera model")
+	h.Set("SERIALNO", s.SerialNumber, "camera serial number")
+	if s.ReadoutMode != "" {
+		h.Set("READMODE", s.ReadoutMode, "readout mode")
+	}
+	if !math.IsNaN(s.ExposureTime) {
+		h.Set("EXPTIME", s.ExposureTime, "[s] integration time")
+	}
+	if !math.IsNaN(s.FrameRate) {
+		h.Set("FRAMERAT", s.FrameRate, "[Hz] frame rate")
+	}
+	if !math.IsNaN(s.SensorTemperature) {
+		h.Set("DET-TEMP", s.SensorTemperature, "[C] sensor temperature")
+	}
+	h.Set("XOFFSET", s.Geometry.OffsetX, "[px] crop window offset on the sensor")
+	h.Set("YOFFSET", s.Geometry.OffsetY, "[px] crop window offset on the sensor")
+	h.Set("SEQSTART", s.FirstSequence, "sequence number of the first frame")
+	h.Set("SEQEND", s.SequenceNumber, "sequence number of the last frame")
+	h.Set("NFRAMES", s.Frames, "number of frames averaged")
+	return &h
+}
+
+func outputStats(s app.OutputStats) oas.OutputStats {
+	results := make(oas.OutputStatsOfferResults, len(s.OfferResults))
+	for i, n := range s.OfferResults {
+		results[app.OfferResult(i).String()] = int64(n)
+	}
+	return oas.OutputStats{
+		Name:               s.Config.Name,
+		Channel:            s.Config.Channel,
+		StreamId:           s.Config.StreamID,
+		Decimation:         int32(s.Config.Decimation),
+		MaxMessageLength:   s.MaxMessageLength,
+		State:              oas.PublicationState(s.State.String()),
+		FramesPublished:    int64(s.FramesPublished),
+		FramesAbandoned:    int64(s.FramesAbandoned),
+		PublicationDropped: int64(s.PublicationDropped),
+		NotConnected:       int64(s.NotConnected),
+		Recreated:          int64(s.Recreated),
+		OfferResults:       results,
+		Policy:             policyStats(s.Policy, s.Config.Publish),
+	}
+}
+
+func policyStats(s app.PolicyStats, cfg app.PublishConfig) oas.PolicyStats {
+	return oas.PolicyStats{
+		Policy:            oas.BackPressurePolicy(s.Policy.String()),
+		Timeout:           cfg.Timeout.Seconds(),
+		DropNewestDropped: int64(s.DropNewestDropped),
+		SpinRetries:       int64(s.SpinRetries),
+		SpinTimeouts:      int64(s.SpinTimeouts),
+		BlockRetries:      int64(s.BlockRetries),
+		BlockedSeconds:    s.Blocked.Seconds(),
+		MaxBlockedSeconds: s.MaxBlocked.Seconds(),
+	}
+}
+
+func (h *Handler) GetTransport(ctx context.Context) (*oas.TransportStatus, error) {
+	s := h.transport.Status()
+	status := &oas.TransportStatus{
+		State:      oas.TransportStatusState(s.State.String()),
+		Since:      s.Since,
+		Reconnects: int64(s.Reconnects),
+	}
+	if s.LastError != nil {
+		status.LastError = oas.NewOptString(s.LastError.Error())
+	}
+	return status, nil
+}
+
+func (h *Handler) ListRecordings(ctx context.Context) ([]oas.Recording, error) {
+	if h.archive == nil {
+		return nil, app.ErrArchiveDisabled
+	}
+	recordings, err := h.archive.Recordings()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]oas.Recording, len(recordings))
+	for i, r := range recordings {
+		res[i] = *recording(r)
+	}
+	return res, nil
+}
+
+func (h *Handler) StartRecording(ctx context.Context, req *oas.StartRecording) (*oas.Recording, error) {
+	if h.archive == nil {
+		return nil, app.ErrArchiveDisabled
+	}
+	var r app.Recording
+	err := h.control(ctx, "StartRecording", func(context.Context) (err error) {
+		r, err = h.archive.StartRecording(req.Output.Or(""))
+		return err
+	}, attribute.String("archive.output", req.Output.Or("")))
+	if err != nil {
+		return nil, err
+	}
+	return recording(r), nil
+}
+
+func (h *Handler) StopRecording(ctx context.Context, params oas.StopRecordingParams) (*oas.Recording, error) {
+	if h.archive == nil {
+		return nil, app.ErrArchiveDisabled
+	}
+	var r app.Recording
+	err := h.control(ctx, "StopRecording", func(context.Context) (err error) {
+		r, err = h.archive.StopRecording(params.RecordingId)
+		return err
+	}, attribute.Int64("archive.recording_id", params.RecordingId))
+	if err != nil {
+		return nil, err
+	}
+	return recording(r), nil
+}
+
+func (h *Handler) StartReplay(ctx context.Context, req *oas.ReplayRequest, params oas.StartReplayParams) (*oas.Replay, error) {
+	if h.archive == nil {
+		return nil, app.ErrArchiveDisabled
+	}
+	var r app.ReplayStatus
+	err := h.control(ctx, "StartReplay", func(context.Context) (err error) {
+		r, err = h.archive.StartReplay(params.RecordingId, req.Channel, req.StreamId, req.Rate.Or(1))
+		return err
+	},
+		attribute.Int64("archive.recording_id", params.RecordingId),
+		attribute.String("archive.replay.channel", req.Channel),
+		attribute.Int("archive.replay.stream_id", int(req.StreamId)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return replay(r), nil
+}
+
+func (h *Handler) ListReplays(ctx context.Context) ([]oas.Replay, error) {
+	if h.archive == nil {
+		return nil, app.ErrArchiveDisabled
+	}
+	replays := h.archive.Replays()
+	res := make([]oas.Replay, len(replays))
+	for i, r := range replays {
+		res[i] = *replay(r)
+	}
+	return res, nil
+}
+
+func (h *Handler) StopReplay(ctx context.Context, params oas.StopReplayParams) error {
+	if h.archive == nil {
+		return app.ErrArchiveDisabled
+	}
+	return h.control(ctx, "StopReplay", func(context.Context) error {
+		return h.archive.StopReplay(params.ReplayId)
+	}, attribute.Int64("archive.replay_id", params.ReplayId))
+}
+
+func (h *Handler) NewError(ctx context.Context, err error) *oas.ErrorStatusCode {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, app.ErrOutOfRange):
+		status = http.StatusBadRequest
+	case errors.Is(err, app.ErrNotSupported), errors.Is(err, app.ErrArchiveDisabled):
+		status = http.StatusNotImplemented
+	case errors.Is(err, app.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, app.ErrNotAcquiring):
+		status = http.StatusConflict
+	case errors.Is(err, context.DeadlineExceeded):
+		status = http.StatusGatewayTimeout
+	}
+	return &oas.ErrorStatusCode{
+		StatusCode: status,
+		Response: oas.Error{
+			Message: err.Error(),
+		},
+	}
+}
+
+// control runs a camera control operation in a span carrying the camera
+// serial number. f is passed the context of the span.
+func (h *Handler) control(ctx context.Context, name string, f func(ctx context.Context) error,
+	attrs ...attribute.KeyValue) error {
+	attrs = append(attrs, attribute.String("camera.serial_number", h.cam.SerialNumber()))
+	ctx, span := h.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+	defer span.End()
+
+	if err := f(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (h *Handler) setValue(ctx context.Context, name, key string,
+	set func(float64) (app.LimitedValue, error), value float64) (*oas.LimitedValue, error) {
+	var v app.LimitedValue
+	err := h.control(ctx, name, func(context.Context) (err error) {
+		v, err = set(value)
+		return err
+	}, attribute.Float64(key, value))
+	return limitedValue(v, err)
+}
+
+func geometry(g app.Geometry) *oas.Geometry {
+	return &oas.Geometry{
+		Width:          g.Width,
+		Height:         g.Height,
+		OffsetX:        g.OffsetX,
+		OffsetY:        g.OffsetY,
+		ImageSizeBytes: g.ImageSizeInBytes,
+	}
+}
+
+func recording(r app.Recording) *oas.Recording {
+	res := &oas.Recording{
+		ID:            r.ID,
+		Channel:       r.Channel,
+		StreamId:      r.StreamID,
+		SessionId:     r.SessionID,
+		Active:        r.Active,
+		StartTime:     r.Start,
+		StartPosition: r.StartPosition,
+		StopPosition:  r.StopPosition,
+		Frames:        int64(r.Frames),
+	}
+	if !r.Stop.IsZero() {
+		res.StopTime = oas.NewOptDateTime(r.Stop)
+	}
+	return res
+}
+
+func replay(r app.ReplayStatus) *oas.Replay {
+	return &oas.Replay{
+		ID:          r.ID,
+		RecordingId: r.RecordingID,
+		Channel:     r.Channel,
+		StreamId:    r.StreamID,
+		Rate:        r.Rate,
+		Frames:      int64(r.Frames),
+	}
+}
+
+func limitedValue(v app.LimitedValue, err error) (*oas.LimitedValue, error) {
+	if err != nil {
+		return nil, err
+	}
+	return &oas.LimitedValue{
+		Value: v.Value,
+		Min:   v.Min,
+		Max:   v.Max,
+	}, nil
+}
+
+func (h *Handler) acquisitionState() *oas.AcquisitionState {
+	return &oas.AcquisitionState{
+		Acquiring: h.cam.Acquiring(),
+		Paused:    h.cam.Paused(),
+	}
+}
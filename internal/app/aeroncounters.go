@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/counters"
+	"go.uber.org/zap"
+)
+
+// CounterTypeID is the type of the counters the service allocates on the
+// media driver, above the types Aeron reserves for itself.
+const CounterTypeID int32 = 1100
+
+// DefaultCounterInterval is the default interval at which the Aeron
+// counters are updated from the service statistics.
+const DefaultCounterInterval = 100 * time.Millisecond
+
+// counterAllocateTimeout bounds waiting for the media driver to allocate a
+// counter.
+const counterAllocateTimeout = 5 * time.Second
+
+// aeronCounter is a counter allocated on the media driver and the
+// statistic it is updated from.
+type aeronCounter struct {
+	label   string
+	value   func() int64
+	counter *aeron.Counter
+}
+
+// AeronCounters mirrors the frame pipeline statistics of a camera in Aeron
+// counters, so that AeronStat shows the camera health next to the
+// publication positions. Frame rates are in mHz and temperatures in m°C, as
+// counters are integers.
+type AeronCounters struct {
+	lg       *zap.Logger
+	interval time.Duration
+
+	mu       sync.Mutex
+	counters []aeronCounter
+}
+
+// NewAeronCounters returns the counters of cam, updated at every interval
+// once allocated.
+func NewAeronCounters(lg *zap.Logger, cam *FLICamera, interval time.Duration) *AeronCounters {
+	if interval <= 0 {
+		interval = DefaultCounterInterval
+	}
+	streams := make([]string, len(cam.outputs))
+	for i, o := range cam.outputs {
+		streams[i] = strconv.Itoa(int(o.config.StreamID))
+	}
+	camLabel := func(name string) string {
+		return fmt.Sprintf("flicamera %s: serial=%s streams=%s",
+			name, cam.SerialNumber(), strings.Join(streams, ","))
+	}
+	counter := func(v func() uint64) func() int64 {
+		return func() int64 { return int64(v()) }
+	}
+	milli := func(v *atomicFloat64) func() int64 {
+		last := int64(0)
+		return func() int64 {
+			// Unknown values keep the last one
+			if f := v.Load(); !math.IsNaN(f) {
+				last = int64(math.Round(f * 1000))
+			}
+			return last
+		}
+	}
+
+	c := &AeronCounters{
+		lg:       lg.Named("counters"),
+		interval: interval,
+	}
+	c.add(camLabel("frames received"), counter(cam.stats.framesReceived.Load))
+	c.add(camLabel("grabber dropped"), counter(cam.stats.grabberDropped.Load))
+	c.add(camLabel("queue overruns"), counter(cam.stats.queueOverruns.Load))
+	c.add(camLabel("frame rate mHz"), milli(&cam.stats.frameRate))
+	c.add(camLabel("sensor temperature m°C"), milli(&cam.metadata.sensorTemperature))
+	for _, o := range cam.outputs {
+		o := o
+		label := func(name string) string {
+			return fmt.Sprintf("flicamera %s: serial=%s stream=%d output=%s",
+				name, cam.SerialNumber(), o.config.StreamID, o.config.Name)
+		}
+		c.add(label("frames published"), counter(o.stats.framesPublished.Load))
+		c.add(label("frames dropped"), counter(o.stats.publicationDropped.Load))
+		c.add(label("frames not connected"), counter(o.stats.notConnected.Load))
+		c.add(label("back pressured"), counter(o.stats.offerResults[OfferBackPressured].Load))
+	}
+	return c
+}
+
+func (c *AeronCounters) add(label string, value func() int64) {
+	if len(label) > int(counters.MaxLabelLength) {
+		label = label[:counters.MaxLabelLength]
+	}
+	c.counters = append(c.counters, aeronCounter{label: label, value: value})
+}
+
+// Allocate allocates the counters with client, replacing those of a
+// previous client. It is an OnConnect hook of the Transport.
+func (c *AeronCounters) Allocate(client *aeron.Aeron) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.release()
+	for i := range c.counters {
+		counter, err := allocateCounter(client, c.counters[i].label)
+		if err != nil {
+			c.release()
+			return fmt.Errorf("aeron counter %q: %w", c.counters[i].label, err)
+		}
+		c.counters[i].counter = counter
+	}
+	c.update()
+	return nil
+}
+
+func allocateCounter(client *aeron.Aeron, label string) (*aeron.Counter, error) {
+	id, err := client.AddCounterByLabel(CounterTypeID, label)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(counterAllocateTimeout)
+	for {
+		counter, err := client.FindCounter(id)
+		if counter != nil || err != nil {
+			return counter, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("no response from the media driver after %v", counterAllocateTimeout)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Release forgets the counters after the client lost the media driver,
+// which frees them. It is an OnDisconnect hook of the Transport.
+func (c *AeronCounters) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.counters {
+		c.counters[i].counter = nil
+	}
+}
+
+// release closes the counters. c.mu must be held.
+func (c *AeronCounters) release() {
+	for i := range c.counters {
+		if counter := c.counters[i].counter; counter != nil {
+			if err := counter.Close(); err != nil {
+				c.lg.Warn("Close counter", zap.String("label", c.counters[i].label), zap.Error(err))
+			}
+			c.counters[i].counter = nil
+		}
+	}
+}
+
+// update copies the statistics to the allocated counters. c.mu must be
+// held.
+func (c *AeronCounters) update() {
+	for i := range c.counters {
+		if counter := c.counters[i].counter; counter != nil {
+			counter.Counter().Set(c.counters[i].value())
+		}
+	}
+}
+
+// Run updates the counters at every interval until ctx is done, then
+// closes them.
+func (c *AeronCounters) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.mu.Lock()
+			c.release()
+			c.mu.Unlock()
+			return nil
+		case <-ticker.C:
+		}
+		c.mu.Lock()
+		c.update()
+		c.mu.Unlock()
+	}
+}
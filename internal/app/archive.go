@@ -0,0 +1,673 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lirm/aeron-go/aeron"
+	aeronatomic "github.com/lirm/aeron-go/aeron/atomic"
+	"github.com/lirm/aeron-go/aeron/idlestrategy"
+	"github.com/lirm/aeron-go/aeron/logbuffer"
+	"github.com/lirm/aeron-go/aeron/logbuffer/term"
+	"github.com/lirm/aeron-go/archive"
+	"github.com/lirm/aeron-go/archive/codecs"
+	"go.uber.org/zap"
+
+	"github.com/New-Earth-Lab/flicameraservice/pkg/framecodec"
+)
+
+var (
+	// ErrArchiveDisabled is returned by recording operations when the
+	// service was started without an Aeron Archive.
+	ErrArchiveDisabled = errors.New("aeron archive disabled")
+	// ErrNotFound is returned for unknown recordings and replays.
+	ErrNotFound = errors.New("not found")
+)
+
+// ArchiveClient is the part of an archive.Archive the recordings are made
+// and replayed with.
+type ArchiveClient interface {
+	StartRecording(channel string, stream int32, isLocal bool, autoStop bool) (int64, error)
+	StopRecordingBySubscriptionId(subscriptionID int64) error
+	StopRecordingByIdentity(recordingID int64) (bool, error)
+	ListRecordings(fromRecordingID int64, recordCount int32) ([]*codecs.RecordingDescriptor, error)
+	ListRecording(recordingID int64) (*codecs.RecordingDescriptor, error)
+	GetRecordingPosition(recordingID int64) (int64, error)
+	StartReplay(recordingID int64, position int64, length int64, replayChannel string, replayStream int32) (int64, error)
+	StopReplay(replaySessionID int64) error
+	Close() error
+}
+
+// ArchiveFactory connects to the archive.
+type ArchiveFactory func() (ArchiveClient, error)
+
+// Subscription is the part of an aeron.Subscription replays are received
+// with.
+type Subscription interface {
+	Poll(handler term.FragmentHandler, fragmentLimit int) int
+	IsConnected() bool
+	Close() error
+}
+
+// SubscriptionFactory adds a subscription.
+type SubscriptionFactory func(channel string, streamID int32) (Subscription, error)
+
+// ArchiveConfig configures the recordings and replays.
+type ArchiveConfig struct {
+	// ReplayChannel and ReplayStreamID are where the archive replays a
+	// recording to the service, which republishes its frames paced on the
+	// chosen channel and stream.
+	ReplayChannel  string
+	ReplayStreamID int32
+}
+
+const (
+	// recordingPage is the number of recordings listed per request.
+	recordingPage = 100
+	// recordingStartTimeout bounds waiting for the archive to start
+	// recording a publication.
+	recordingStartTimeout = 5 * time.Second
+	// replayConnectTimeout bounds waiting for the replay image.
+	replayConnectTimeout = 5 * time.Second
+	// replayFragmentLimit is the number of fragments handled per poll.
+	replayFragmentLimit = 16
+	// nullTimestamp is the stop timestamp of active recordings.
+	nullTimestamp = -1
+)
+
+// Recording is an Aeron Archive recording of an output.
+type Recording struct {
+	ID        int64
+	Channel   string
+	StreamID  int32
+	SessionID int32
+	// Active is set while the archive records the stream; Stop is zero
+	// and StopPosition the current position meanwhile.
+	Active        bool
+	Start         time.Time
+	Stop          time.Time
+	StartPosition int64
+	StopPosition  int64
+	// Frames is the number of frames recorded, estimated from the recorded
+	// bytes and the frame length when the recording started, or the current
+	// frame length for recordings started before the service.
+	Frames uint64
+}
+
+// ReplayStatus is the progress of a replay.
+type ReplayStatus struct {
+	ID          int64
+	RecordingID int64
+	Channel     string
+	StreamID    int32
+	// Rate is the replay speed relative to the recording, 0 for unpaced.
+	Rate float64
+	// Frames is the number of frames republished, not counting those
+	// dropped while the replay channel had no subscribers.
+	Frames uint64
+}
+
+// Archive records the outputs of a camera with an Aeron Archive and
+// replays the recordings. The archive replays at its own pace, so replays
+// are received by the service and republished paced by the frame
+// timestamps, in the wire format of the live stream.
+type Archive struct {
+	lg        *zap.Logger
+	cam       *FLICamera
+	config    ArchiveConfig
+	connect   ArchiveFactory
+	subscribe SubscriptionFactory
+	publish   PublicationFactory
+
+	// ctx ends the replays when Run returns
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	client  ArchiveClient
+	replays map[int64]*replay
+	// messageLengths are the frame lengths of the recordings started by
+	// the service, when they started.
+	messageLengths map[int64]int32
+}
+
+// NewArchive returns the archive of the outputs of cam. The archive is
+// connected with connect on first use; replays are received with
+// subscriptions from subscribe and republished to publications from
+// publish.
+func NewArchive(lg *zap.Logger, cam *FLICamera, config ArchiveConfig, connect ArchiveFactory,
+	subscribe SubscriptionFactory, publish PublicationFactory) *Archive {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Archive{
+		lg:        lg.Named("archive"),
+		cam:       cam,
+		config:    config,
+		connect:   connect,
+		subscribe: subscribe,
+		publish:   publish,
+		ctx:       ctx,
+		cancel:    cancel,
+		replays:   make(map[int64]*replay),
+
+		messageLengths: make(map[int64]int32),
+	}
+}
+
+// connected returns the client, connecting it if needed. a.mu must be
+// held.
+func (a *Archive) connected() (ArchiveClient, error) {
+	if a.client == nil {
+		client, err := a.connect()
+		if err != nil {
+			return nil, fmt.Errorf("aeron archive connect: %w", err)
+		}
+		a.client = client
+	}
+	return a.client, nil
+}
+
+// Release forgets the client after the media driver was lost, closing it in
+// the background. It is an OnDisconnect hook of the Transport.
+func (a *Archive) Release() {
+	a.mu.Lock()
+	client := a.client
+	a.client = nil
+	a.mu.Unlock()
+
+	if client != nil {
+		go client.Close()
+	}
+}
+
+// output returns the output named name, or the first one if name is empty.
+func (a *Archive) output(name string) (*Output, error) {
+	for _, o := range a.cam.outputs {
+		if name == "" || o.config.Name == name {
+			return o, nil
+		}
+	}
+	return nil, fmt.Errorf("output %s: %w", name, ErrNotFound)
+}
+
+// StartRecording starts recording the output named name, or the first
+// output if name is empty.
+func (a *Archive) StartRecording(name string) (Recording, error) {
+	o, err := a.output(name)
+	if err != nil {
+		return Recording{}, err
+	}
+
+	a.mu.Lock()
+	client, err := a.connected()
+	if err != nil {
+		a.mu.Unlock()
+		return Recording{}, err
+	}
+	// Recordings already in the catalog are not the new one
+	last := int64(-1)
+	descriptors, err := listRecordings(client, 0)
+	if err != nil {
+		a.mu.Unlock()
+		return Recording{}, err
+	}
+	for _, d := range descriptors {
+		if d.RecordingId > last {
+			last = d.RecordingId
+		}
+	}
+
+	messageLength := a.cam.MessageLength()
+	subscriptionID, err := client.StartRecording(o.config.Channel, o.config.StreamID, true, false)
+	a.mu.Unlock()
+	if err != nil {
+		return Recording{}, err
+	}
+
+	// The recording is created once the archive sees the publication. The
+	// lock is only held for the requests, not while waiting.
+	sessionID, haveSession := o.SessionID()
+	deadline := time.Now().Add(recordingStartTimeout)
+	for {
+		r, found, err := a.findRecording(client, last+1, o.config.StreamID, sessionID, haveSession, messageLength)
+		if err != nil {
+			return Recording{}, err
+		}
+		if found {
+			a.lg.Info("Recording started",
+				zap.Int64("recordingId", r.ID),
+				zap.Int64("subscriptionId", subscriptionID),
+				zap.String("output", o.config.Name),
+			)
+			return r, nil
+		}
+		if time.Now().After(deadline) {
+			a.mu.Lock()
+			if a.client == client {
+				if err := client.StopRecordingBySubscriptionId(subscriptionID); err != nil {
+					a.lg.Warn("Stop recording", zap.Error(err))
+				}
+			}
+			a.mu.Unlock()
+			return Recording{}, fmt.Errorf("output %s not recorded after %v", o.config.Name, recordingStartTimeout)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// findRecording looks for the recording of streamID, and of sessionID if
+// haveSession, from the recording id from on, and keeps its messageLength.
+// It fails if the client was released meanwhile.
+func (a *Archive) findRecording(client ArchiveClient, from int64, streamID, sessionID int32, haveSession bool,
+	messageLength int32) (Recording, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.client != client {
+		return Recording{}, false, errors.New("aeron archive disconnected while starting the recording")
+	}
+	descriptors, err := listRecordings(client, from)
+	if err != nil {
+		return Recording{}, false, err
+	}
+	for _, d := range descriptors {
+		if d.StreamId == streamID && (!haveSession || d.SessionId == sessionID) {
+			a.messageLengths[d.RecordingId] = messageLength
+			return a.recording(client, d), true, nil
+		}
+	}
+	return Recording{}, false, nil
+}
+
+// StopRecording stops the recording with id.
+func (a *Archive) StopRecording(id int64) (Recording, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	client, err := a.connected()
+	if err != nil {
+		return Recording{}, err
+	}
+	stopped, err := client.StopRecordingByIdentity(id)
+	if err != nil {
+		return Recording{}, err
+	}
+	if !stopped {
+		return Recording{}, fmt.Errorf("active recording %d: %w", id, ErrNotFound)
+	}
+	d, err := client.ListRecording(id)
+	if err != nil {
+		return Recording{}, err
+	}
+	if d == nil {
+		return Recording{}, fmt.Errorf("recording %d: %w", id, ErrNotFound)
+	}
+	a.lg.Info("Recording stopped", zap.Int64("recordingId", id))
+	return a.recording(client, d), nil
+}
+
+// Recordings lists the recordings of the stream ids of the outputs.
+func (a *Archive) Recordings() ([]Recording, error) {
+	streams := make(map[int32]bool, len(a.cam.outputs))
+	for _, o := range a.cam.outputs {
+		streams[o.config.StreamID] = true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	client, err := a.connected()
+	if err != nil {
+		return nil, err
+	}
+	descriptors, err := listRecordings(client, 0)
+	if err != nil {
+		return nil, err
+	}
+	recordings := make([]Recording, 0, len(descriptors))
+	for _, d := range descriptors {
+		if streams[d.StreamId] {
+			recordings = append(recordings, a.recording(client, d))
+		}
+	}
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].ID < recordings[j].ID
+	})
+	return recordings, nil
+}
+
+// listRecordings lists the recordings of the catalog from id on.
+func listRecordings(client ArchiveClient, id int64) ([]*codecs.RecordingDescriptor, error) {
+	var descriptors []*codecs.RecordingDescriptor
+	for {
+		page, err := client.ListRecordings(id, recordingPage)
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, page...)
+		if len(page) < recordingPage {
+			return descriptors, nil
+		}
+		id = page[len(page)-1].RecordingId + 1
+	}
+}
+
+// recording converts a recording descriptor, reading the position of
+// active recordings. a.mu must be held.
+func (a *Archive) recording(client ArchiveClient, d *codecs.RecordingDescriptor) Recording {
+	r := Recording{
+		ID:            d.RecordingId,
+		Channel:       string(d.OriginalChannel),
+		StreamID:      d.StreamId,
+		SessionID:     d.SessionId,
+		Start:         time.UnixMilli(d.StartTimestamp),
+		StartPosition: d.StartPosition,
+		StopPosition:  d.StopPosition,
+	}
+	if d.StopPosition == archive.RecordingPositionNull {
+		r.Active = true
+		r.StopPosition = d.StartPosition
+		if position, err := client.GetRecordingPosition(d.RecordingId); err == nil && position != archive.RecordingPositionNull {
+			r.StopPosition = position
+		}
+	}
+	if d.StopTimestamp != nullTimestamp && !r.Active {
+		r.Stop = time.UnixMilli(d.StopTimestamp)
+	}
+	messageLength, ok := a.messageLengths[d.RecordingId]
+	if !ok {
+		messageLength = a.cam.MessageLength()
+	}
+	r.Frames = recordedFrames(r.StopPosition-r.StartPosition, messageLength, d.MtuLength)
+	return r
+}
+
+// recordedFrames returns the number of messages of messageLength bytes in
+// length bytes of log, where messages longer than an mtu are fragmented
+// into frames aligned on 32 bytes, each with its header.
+func recordedFrames(length int64, messageLength, mtu int32) uint64 {
+	headerLength := logbuffer.DataFrameHeader.Length
+	if length <= 0 || messageLength <= 0 || mtu <= headerLength {
+		return 0
+	}
+	maxPayload := int64(mtu - headerLength)
+	full, rest := int64(messageLength)/maxPayload, int64(messageLength)%maxPayload
+	span := full * align(maxPayload+int64(headerLength))
+	if rest > 0 {
+		span += align(rest + int64(headerLength))
+	}
+	return uint64(length / span)
+}
+
+// align rounds a frame length up to the frame alignment.
+func align(length int64) int64 {
+	const alignment = int64(logbuffer.FrameAlignment)
+	return (length + alignment - 1) &^ (alignment - 1)
+}
+
+// replay republishes a recording replayed by the archive.
+type replay struct {
+	ReplayStatus
+	cancel context.CancelFunc
+	frames atomic.Uint64
+}
+
+// StartReplay replays the recording with id onto channel and streamID at
+// rate times the recorded frame rate, or as fast as possible if rate is 0.
+func (a *Archive) StartReplay(id int64, channel string, streamID int32, rate float64) (ReplayStatus, error) {
+	if rate < 0 {
+		return ReplayStatus{}, fmt.Errorf("%w: replay rate %v", ErrOutOfRange, rate)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	client, err := a.connected()
+	if err != nil {
+		return ReplayStatus{}, err
+	}
+	d, err := client.ListRecording(id)
+	if err != nil {
+		return ReplayStatus{}, err
+	}
+	if d == nil {
+		return ReplayStatus{}, fmt.Errorf("recording %d: %w", id, ErrNotFound)
+	}
+	// Active recordings are replayed up to their current position
+	stop := d.StopPosition
+	if stop == archive.RecordingPositionNull {
+		if stop, err = client.GetRecordingPosition(id); err != nil {
+			return ReplayStatus{}, err
+		}
+	}
+
+	publication, err := a.publish(channel, streamID)
+	if err != nil {
+		return ReplayStatus{}, err
+	}
+	sessionID, err := client.StartReplay(id, d.StartPosition, stop-d.StartPosition,
+		a.config.ReplayChannel, a.config.ReplayStreamID)
+	if err != nil {
+		closePublication(publication)
+		return ReplayStatus{}, err
+	}
+	// Only the image of this replay is received
+	replayChannel, err := archive.AddSessionIdToChannel(a.config.ReplayChannel,
+		archive.ReplaySessionIdToStreamId(sessionID))
+	var subscription Subscription
+	if err == nil {
+		subscription, err = a.subscribe(replayChannel, a.config.ReplayStreamID)
+	}
+	if err != nil {
+		closePublication(publication)
+		client.StopReplay(sessionID)
+		return ReplayStatus{}, err
+	}
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	r := &replay{
+		ReplayStatus: ReplayStatus{
+			ID:          sessionID,
+			RecordingID: id,
+			Channel:     channel,
+			StreamID:    streamID,
+			Rate:        rate,
+		},
+		cancel: cancel,
+	}
+	a.replays[sessionID] = r
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		err := r.run(ctx, subscription, publication)
+		cancel()
+		subscription.Close()
+		closePublication(publication)
+
+		a.mu.Lock()
+		delete(a.replays, sessionID)
+		// A replay interrupted before its end is stopped on the archive
+		if err != nil && a.client == client {
+			if serr := client.StopReplay(sessionID); serr != nil {
+				a.lg.Debug("Stop replay", zap.Int64("replayId", sessionID), zap.Error(serr))
+			}
+		}
+		a.mu.Unlock()
+
+		lg := a.lg.With(zap.Int64("replayId", sessionID), zap.Uint64("frames", r.frames.Load()))
+		if err != nil && !errors.Is(err, context.Canceled) {
+			lg.Warn("Replay failed", zap.Error(err))
+		} else {
+			lg.Info("Replay ended")
+		}
+	}()
+	a.lg.Info("Replay started",
+		zap.Int64("replayId", sessionID),
+		zap.Int64("recordingId", id),
+		zap.String("channel", channel),
+		zap.Int32("streamId", streamID),
+		zap.Float64("rate", rate),
+	)
+	return r.ReplayStatus, nil
+}
+
+// StopReplay stops the replay with id.
+func (a *Archive) StopReplay(id int64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	r, ok := a.replays[id]
+	if !ok {
+		return fmt.Errorf("replay %d: %w", id, ErrNotFound)
+	}
+	r.cancel()
+	return nil
+}
+
+// Replays returns the replays in progress.
+func (a *Archive) Replays() []ReplayStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	replays := make([]ReplayStatus, 0, len(a.replays))
+	for _, r := range a.replays {
+		s := r.ReplayStatus
+		s.Frames = r.frames.Load()
+		replays = append(replays, s)
+	}
+	sort.Slice(replays, func(i, j int) bool {
+		return replays[i].ID < replays[j].ID
+	})
+	return replays
+}
+
+// Run ends the replays and closes the client when ctx is done.
+func (a *Archive) Run(ctx context.Context) error {
+	<-ctx.Done()
+	a.cancel()
+	a.wg.Wait()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.client != nil {
+		err := a.client.Close()
+		a.client = nil
+		return err
+	}
+	return nil
+}
+
+// run republishes the replayed frames until the replay image goes away, or
+// returns ctx.Err() if interrupted.
+func (r *replay) run(ctx context.Context, subscription Subscription, publication Publication) error {
+	deadline := time.Now().Add(replayConnectTimeout)
+	for !subscription.IsConnected() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("no replay image after %v", replayConnectTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+
+	var (
+		err        error
+		first      = true
+		start      time.Time
+		firstFrame int64
+		maxLength  = MaxMessageLength(publication)
+	)
+	handler := func(buf *aeronatomic.Buffer, offset, length int32, _ *logbuffer.Header) {
+		if err != nil {
+			return
+		}
+		if maxLength > 0 && length > maxLength {
+			err = fmt.Errorf("%d byte frame exceeds the %d byte max message length of the replay channel",
+				length, maxLength)
+			return
+		}
+		if timestamp, ok := frameTimestamp(buf, offset, length); ok && r.Rate > 0 {
+			if first {
+				start, firstFrame, first = time.Now(), timestamp, false
+			}
+			due := start.Add(time.Duration(float64(timestamp-firstFrame) / r.Rate))
+			if wait := time.Until(due); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					err = ctx.Err()
+					return
+				case <-timer.C:
+				}
+			}
+		}
+		var published bool
+		published, err = offerReplayed(ctx, publication, buf, offset, length)
+		if published {
+			r.frames.Add(1)
+		}
+	}
+	assembler := aeron.NewFragmentAssembler(handler, aeron.DefaultFragmentAssemblyBufferLength)
+
+	idle := idlestrategy.NewDefaultBackoffIdleStrategy()
+	for err == nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		n := subscription.Poll(assembler.OnFragment, replayFragmentLimit)
+		if n == 0 && !subscription.IsConnected() {
+			return nil
+		}
+		idle.Idle(n)
+	}
+	return err
+}
+
+// offerReplayed offers a replayed frame, waiting while back pressured, and
+// reports whether it was published. Frames are dropped while the replay
+// channel has no subscribers.
+func offerReplayed(ctx context.Context, publication Publication, buf *aeronatomic.Buffer, offset, length int32) (bool, error) {
+	idle := idlestrategy.NewDefaultBackoffIdleStrategy()
+	for {
+		ret := publication.Offer2(buf, offset, length, buf, offset+length, 0, nil)
+		switch {
+		case ret >= 0:
+			return true, nil
+		case ret == aeron.NotConnected:
+			return false, nil
+		case ret == aeron.BackPressured, ret == aeron.AdminAction:
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			idle.Idle(0)
+		default:
+			return false, fmt.Errorf("replay publication: %v", offerResult(ret))
+		}
+	}
+}
+
+// frameTimestamp returns the TimestampNs of a frame message.
+func frameTimestamp(buf *aeronatomic.Buffer, offset, length int32) (int64, bool) {
+	var hdr framecodec.MessageHeader
+	var frame framecodec.ImageFrame
+	if length < framecodec.MessageHeaderEncodedLength {
+		return 0, false
+	}
+	hdr.Wrap(buf, offset)
+	blockLength := int32(hdr.BlockLength())
+	if hdr.SchemaID() != framecodec.SchemaID || hdr.TemplateID() != framecodec.ImageFrameTemplateID ||
+		length < framecodec.MessageHeaderEncodedLength+blockLength {
+		return 0, false
+	}
+	frame.WrapForDecode(buf, offset+framecodec.MessageHeaderEncodedLength, blockLength, hdr.Version())
+	return frame.TimestampNs(), true
+}
@@ -0,0 +1,422 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/atomic"
+	"github.com/lirm/aeron-go/aeron/logbuffer"
+	"github.com/lirm/aeron-go/aeron/logbuffer/term"
+	"github.com/lirm/aeron-go/archive/codecs"
+	"go.uber.org/zap"
+
+	"github.com/New-Earth-Lab/flicameraservice/pkg/framecodec"
+)
+
+// standInMTU keeps the test frames in a single fragment.
+const standInMTU = 65504
+
+// standInArchive is an in-process stand-in for the Aeron Archive protocol.
+// Recordings hold the frame messages the test records into them and are
+// replayed to the standInSubscription of the replay session.
+type standInArchive struct {
+	mu            sync.Mutex
+	descriptors   []*codecs.RecordingDescriptor
+	messages      map[int64][][]byte
+	replays       map[int64][][]byte
+	stopped       map[int64]bool
+	subscriptions int64
+}
+
+func newStandInArchive() *standInArchive {
+	return &standInArchive{
+		messages: make(map[int64][][]byte),
+		replays:  make(map[int64][][]byte),
+		stopped:  make(map[int64]bool),
+	}
+}
+
+func (s *standInArchive) StartRecording(channel string, stream int32, _ bool, _ bool) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.descriptors = append(s.descriptors, &codecs.RecordingDescriptor{
+		RecordingId:     int64(len(s.descriptors)),
+		StartTimestamp:  time.Now().UnixMilli(),
+		StopTimestamp:   nullTimestamp,
+		StartPosition:   0,
+		StopPosition:    -1,
+		MtuLength:       standInMTU,
+		StreamId:        stream,
+		OriginalChannel: []byte(channel),
+	})
+	s.subscriptions++
+	return s.subscriptions, nil
+}
+
+func (s *standInArchive) StopRecordingBySubscriptionId(int64) error {
+	return nil
+}
+
+func (s *standInArchive) StopRecordingByIdentity(id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 0 || id >= int64(len(s.descriptors)) || s.descriptors[id].StopPosition != -1 {
+		return false, nil
+	}
+	d := s.descriptors[id]
+	d.StopPosition = s.position(id)
+	d.StopTimestamp = time.Now().UnixMilli()
+	return true, nil
+}
+
+// record appends frame messages to the recording with id.
+func (s *standInArchive) record(id int64, messages ...[]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages[id] = append(s.messages[id], messages...)
+}
+
+// position returns the position of the recording with id. s.mu must be
+// held.
+func (s *standInArchive) position(id int64) int64 {
+	position := int64(0)
+	for _, m := range s.messages[id] {
+		position += (int64(len(m)) + 32 + 31) &^ 31
+	}
+	return position
+}
+
+func (s *standInArchive) ListRecordings(from int64, count int32) ([]*codecs.RecordingDescriptor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []*codecs.RecordingDescriptor
+	for _, d := range s.descriptors {
+		if d.RecordingId >= from && len(res) < int(count) {
+			c := *d
+			res = append(res, &c)
+		}
+	}
+	return res, nil
+}
+
+func (s *standInArchive) ListRecording(id int64) (*codecs.RecordingDescriptor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 0 || id >= int64(len(s.descriptors)) {
+		return nil, nil
+	}
+	c := *s.descriptors[id]
+	return &c, nil
+}
+
+func (s *standInArchive) GetRecordingPosition(id int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 0 || id >= int64(len(s.descriptors)) || s.descriptors[id].StopPosition != -1 {
+		return -1, nil
+	}
+	return s.position(id), nil
+}
+
+func (s *standInArchive) StartReplay(id int64, position int64, length int64, _ string, _ int32) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id < 0 || id >= int64(len(s.descriptors)) {
+		return 0, errors.New("unknown recording")
+	}
+	if position != 0 || length != s.descriptors[id].StopPosition {
+		return 0, errors.New("replay of part of the recording")
+	}
+	session := int64(1000 + len(s.replays))
+	s.replays[session] = s.messages[id]
+	return session, nil
+}
+
+func (s *standInArchive) StopReplay(session int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stopped[session] = true
+	return nil
+}
+
+func (s *standInArchive) Close() error {
+	return nil
+}
+
+// subscribe returns the subscription of the replay session in channel.
+func (s *standInArchive) subscribe(channel string, _ int32) (Subscription, error) {
+	uri, err := aeron.ParseChannelUri(channel)
+	if err != nil {
+		return nil, err
+	}
+	session, err := strconv.ParseInt(uri.Get("session-id"), 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &standInSubscription{messages: s.replays[session]}, nil
+}
+
+// standInSubscription delivers the messages of a replay unfragmented, then
+// disconnects.
+type standInSubscription struct {
+	messages [][]byte
+	header   [32]byte
+}
+
+func (s *standInSubscription) Poll(handler term.FragmentHandler, limit int) int {
+	s.header[logbuffer.DataFrameHeader.FlagsFieldOffset] = 0xC0
+	var header logbuffer.Header
+	header.Wrap(unsafe.Pointer(&s.header[0]), int32(len(s.header)))
+
+	n := 0
+	for ; n < limit && len(s.messages) > 0; n++ {
+		m := s.messages[0]
+		s.messages = s.messages[1:]
+		handler(atomic.MakeBuffer(m), 0, int32(len(m)), &header)
+	}
+	return n
+}
+
+func (s *standInSubscription) IsConnected() bool {
+	return len(s.messages) > 0
+}
+
+func (s *standInSubscription) Close() error {
+	return nil
+}
+
+// capturePublication keeps the messages offered to it.
+type capturePublication struct {
+	mu       sync.Mutex
+	messages [][]byte
+}
+
+func (p *capturePublication) Offer2(bufferOne *atomic.Buffer, offsetOne int32, lengthOne int32,
+	bufferTwo *atomic.Buffer, offsetTwo int32, lengthTwo int32, _ term.ReservedValueSupplier) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	m := append(bufferOne.GetBytesArray(offsetOne, lengthOne), bufferTwo.GetBytesArray(offsetTwo, lengthTwo)...)
+	p.messages = append(p.messages, m)
+	return int64(len(p.messages))
+}
+
+func (p *capturePublication) captured() [][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.messages
+}
+
+// frameMessage returns a frame message of length bytes with a sequence
+// number and timestamp.
+func frameMessage(length int32, sequence, timestamp int64) []byte {
+	var hdr framecodec.MessageHeader
+	var frame framecodec.ImageFrame
+	m := make([]byte, length)
+	frame.WrapAndApplyHeader(atomic.MakeBuffer(m), 0, &hdr)
+	frame.SetTimestampNs(timestamp)
+	frame.SetSequenceNumber(sequence)
+	return m
+}
+
+func newTestArchive(t *testing.T) (*Archive, *standInArchive, *capturePublication) {
+	t.Helper()
+
+	output, err := NewOutput(zap.NewNop(), OutputConfig{
+		Name:     "live",
+		Channel:  "aeron:ipc",
+		StreamID: 1001,
+	}, &benchPublication{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cam, err := NewFliCamera(zap.NewNop(), FliConfig{
+		Width:        16,
+		Height:       16,
+		SerialNumber: "test",
+	}, benchBackend{}, []*Output{output})
+	if err != nil {
+		t.Fatal(err)
+	}
+	standIn := newStandInArchive()
+	publication := new(capturePublication)
+	a := NewArchive(zap.NewNop(), cam, ArchiveConfig{
+		ReplayChannel:  "aeron:ipc",
+		ReplayStreamID: 1099,
+	}, func() (ArchiveClient, error) {
+		return standIn, nil
+	}, standIn.subscribe, func(string, int32) (Publication, error) {
+		return publication, nil
+	})
+	t.Cleanup(a.cancel)
+	return a, standIn, publication
+}
+
+func TestArchiveRecording(t *testing.T) {
+	a, standIn, _ := newTestArchive(t)
+	length := a.cam.MessageLength()
+
+	if _, err := a.StartRecording("other"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("StartRecording of an unknown output: %v, want ErrNotFound", err)
+	}
+	r, err := a.StartRecording("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Active || r.StreamID != 1001 || r.Channel != "aeron:ipc" {
+		t.Fatalf("started recording %+v", r)
+	}
+
+	for i := int64(0); i < 5; i++ {
+		standIn.record(r.ID, frameMessage(length, i, i*int64(time.Millisecond)))
+	}
+	// Frames are counted with their length when the recording started
+	if _, err := a.cam.SetROI(8, 8, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	recordings, err := a.Recordings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordings) != 1 || !recordings[0].Active || recordings[0].Frames != 5 {
+		t.Fatalf("recordings %+v, want one active recording of 5 frames", recordings)
+	}
+
+	r, err = a.StopRecording(r.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Active || r.Stop.IsZero() || r.Frames != 5 {
+		t.Fatalf("stopped recording %+v, want 5 frames", r)
+	}
+	if _, err := a.StopRecording(r.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("StopRecording of a stopped recording: %v, want ErrNotFound", err)
+	}
+}
+
+func TestArchiveReplay(t *testing.T) {
+	const (
+		frames = 5
+		period = 20 * time.Millisecond
+	)
+	for _, tc := range []struct {
+		name     string
+		rate     float64
+		min, max time.Duration
+	}{
+		{"original", 1, (frames - 1) * period, time.Second},
+		{"accelerated", 4, (frames - 1) * period / 4, (frames - 1) * period},
+		{"unpaced", 0, 0, (frames - 1) * period / 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, standIn, publication := newTestArchive(t)
+			length := a.cam.MessageLength()
+
+			r, err := a.StartRecording("live")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := int64(0); i < frames; i++ {
+				standIn.record(r.ID, frameMessage(length, i, i*int64(period)))
+			}
+			if _, err := a.StopRecording(r.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			replay, err := a.StartReplay(r.ID, "aeron:udp?endpoint=localhost:40124", 2001, tc.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(a.Replays()) != 1 {
+				t.Fatalf("replays %+v, want the started replay", a.Replays())
+			}
+			for len(a.Replays()) > 0 {
+				if time.Since(start) > 5*time.Second {
+					t.Fatal("replay did not end")
+				}
+				time.Sleep(time.Millisecond)
+			}
+			elapsed := time.Since(start)
+			if elapsed < tc.min || elapsed > tc.max {
+				t.Errorf("replay took %v, want between %v and %v", elapsed, tc.min, tc.max)
+			}
+
+			messages := publication.captured()
+			if len(messages) != frames {
+				t.Fatalf("%d frames replayed, want %d", len(messages), frames)
+			}
+			for i, m := range messages {
+				if seq := atomic.MakeBuffer(m).GetInt64(16); len(m) != int(length) || seq != int64(i) {
+					t.Errorf("frame %d: %d bytes with sequence %d, want %d bytes with sequence %d",
+						i, len(m), seq, length, i)
+				}
+			}
+			standIn.mu.Lock()
+			stopped := standIn.stopped[replay.ID]
+			standIn.mu.Unlock()
+			if stopped {
+				t.Error("completed replay stopped on the archive")
+			}
+			if err := a.StopReplay(replay.ID); !errors.Is(err, ErrNotFound) {
+				t.Errorf("StopReplay of an ended replay: %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+// notConnectedPublication has no subscribers.
+type notConnectedPublication struct{}
+
+func (notConnectedPublication) Offer2(_ *atomic.Buffer, _ int32, _ int32,
+	_ *atomic.Buffer, _ int32, _ int32, _ term.ReservedValueSupplier) int64 {
+	return aeron.NotConnected
+}
+
+func TestOfferReplayedNotConnected(t *testing.T) {
+	m := frameMessage(256, 0, 0)
+	published, err := offerReplayed(context.Background(), notConnectedPublication{},
+		atomic.MakeBuffer(m), 0, int32(len(m)))
+	if published || err != nil {
+		t.Errorf("offer without subscribers: published %v, error %v, want dropped", published, err)
+	}
+}
+
+func TestRecordedFrames(t *testing.T) {
+	for _, tc := range []struct {
+		length        int64
+		messageLength int32
+		mtu           int32
+		want          uint64
+	}{
+		// 1000 byte messages take 1056 bytes with their header
+		{5 * 1056, 1000, 1408, 5},
+		// 3000 byte messages take two full 1408 byte frames and a 288 byte one
+		{5 * 3104, 3000, 1408, 5},
+		{5*3104 - 1, 3000, 1408, 4},
+		{0, 3000, 1408, 0},
+	} {
+		if got := recordedFrames(tc.length, tc.messageLength, tc.mtu); got != tc.want {
+			t.Errorf("recordedFrames(%d, %d, %d) = %d, want %d",
+				tc.length, tc.messageLength, tc.mtu, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// FrameHandler is called by a CameraBackend for every frame it acquires.
+// The image memory is owned by the backend and is only valid for the
+// duration of the call. counter is the backend image counter, which
+// increases by one per acquired frame; gaps are frames dropped before they
+// reached the handler.
+type FrameHandler func(image unsafe.Pointer, counter uint64)
+
+// Geometry describes the images produced by a configured camera.
+type Geometry struct {
+	Width            int32
+	Height           int32
+	OffsetX          int32
+	OffsetY          int32
+	ImageSizeInBytes int32
+}
+
+// CameraBackend is a source of camera frames. The FLI SDK is one
+// implementation; others allow the service to run without a grabber.
+type CameraBackend interface {
+	// Detect finds and selects the camera with the given serial number and
+	// returns its description.
+	Detect(serialNumber string) (string, error)
+	// Configure applies the acquisition settings and returns the resulting
+	// image geometry.
+	Configure(config FliConfig) (Geometry, error)
+	// SetFrameHandler registers the function called for every new frame.
+	// It must be called before Start.
+	SetFrameHandler(handler FrameHandler)
+	// Start begins acquisition.
+	Start() error
+	// Stop ends acquisition.
+	Stop() error
+	// Shutdown stops acquisition and releases the backend.
+	Shutdown() error
+}
+
+var (
+	// ErrNotSupported is returned for operations the backend does not
+	// implement.
+	ErrNotSupported = errors.New("not supported by camera backend")
+	// ErrOutOfRange is returned when a setting is outside the camera limits.
+	ErrOutOfRange = errors.New("value out of range")
+)
+
+// SensorReporter is implemented by backends that know the size of their
+// sensor, so that cropping windows can be checked before they are applied.
+type SensorReporter interface {
+	SensorSize() (width, height int)
+}
+
+// LimitedValue is a camera setting together with its accepted range.
+type LimitedValue struct {
+	Value float64
+	Min   float64
+	Max   float64
+}
+
+// ExposureController is implemented by backends with an adjustable frame
+// rate and integration time. Setters return the value the camera accepted.
+type ExposureController interface {
+	// FrameRate returns the frame rate in Hz.
+	FrameRate() (LimitedValue, error)
+	SetFrameRate(fps float64) (LimitedValue, error)
+	// IntegrationTime returns the integration time in seconds.
+	IntegrationTime() (LimitedValue, error)
+	SetIntegrationTime(seconds float64) (LimitedValue, error)
+}
+
+// Temperatures are the camera temperature readings in degrees Celsius.
+type Temperatures struct {
+	Sensor      float64
+	Motherboard float64
+	Peltier     float64
+	Heatsink    float64
+	// TECPower is the thermoelectric cooler power in percent.
+	TECPower float64
+}
+
+// ThermalController is implemented by backends with a cooled sensor.
+type ThermalController interface {
+	Temperatures() (Temperatures, error)
+	// SensorSetpoint returns the sensor cooling setpoint in degrees Celsius.
+	SensorSetpoint() (LimitedValue, error)
+	SetSensorSetpoint(celsius float64) (LimitedValue, error)
+}
@@ -0,0 +1,514 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/atomic"
+	"github.com/lirm/aeron-go/aeron/flyweight"
+	"github.com/lirm/aeron-go/aeron/idlestrategy"
+	"github.com/lirm/aeron-go/aeron/logbuffer"
+	"github.com/lirm/aeron-go/aeron/logbuffer/term"
+	"go.uber.org/zap"
+)
+
+// Camera commands are sent on the control stream as ControlCommand
+// messages and answered on the response stream with a ControlResponse
+// carrying the correlation id of the command, a result code and the camera
+// status after the command. All fields are little-endian.
+const ControlVersion = 1
+
+// CommandType identifies a camera command.
+type CommandType int32
+
+const (
+	// CommandStatus only requests the camera status.
+	CommandStatus CommandType = iota + 1
+	CommandStart
+	CommandStop
+	// CommandSetExposure sets the integration time to Value seconds.
+	CommandSetExposure
+	// CommandSetFrameRate sets the frame rate to Value Hz.
+	CommandSetFrameRate
+	// CommandSetROI sets the cropping window to Width, Height, OffsetX and
+	// OffsetY.
+	CommandSetROI
+	numCommandTypes
+)
+
+var commandTypeNames = [numCommandTypes]string{
+	"",
+	"status",
+	"start",
+	"stop",
+	"set-exposure",
+	"set-fps",
+	"set-roi",
+}
+
+func (t CommandType) String() string {
+	if t <= 0 || t >= numCommandTypes {
+		return fmt.Sprintf("command(%d)", int32(t))
+	}
+	return commandTypeNames[t]
+}
+
+// ResultCode is the outcome of a command.
+type ResultCode int32
+
+const (
+	ResultOK ResultCode = iota
+	// ResultMalformed is returned for messages too short or of another
+	// version.
+	ResultMalformed
+	ResultUnknownCommand
+	ResultOutOfRange
+	ResultNotSupported
+	// ResultFailed is returned when the camera failed to apply the command.
+	ResultFailed
+	numResultCodes
+)
+
+var resultCodeNames = [numResultCodes]string{
+	"ok",
+	"malformed",
+	"unknown-command",
+	"out-of-range",
+	"not-supported",
+	"failed",
+}
+
+func (r ResultCode) String() string {
+	if r < 0 || r >= numResultCodes {
+		return fmt.Sprintf("result(%d)", int32(r))
+	}
+	return resultCodeNames[r]
+}
+
+// resultCode maps a camera error to a result code.
+func resultCode(err error) ResultCode {
+	switch {
+	case err == nil:
+		return ResultOK
+	case errors.Is(err, ErrOutOfRange):
+		return ResultOutOfRange
+	case errors.Is(err, ErrNotSupported):
+		return ResultNotSupported
+	default:
+		return ResultFailed
+	}
+}
+
+// CameraState is the acquisition state reported in a ControlResponse.
+type CameraState int32
+
+const (
+	CameraStopped CameraState = iota
+	CameraAcquiring
+	// CameraPaused means acquisition is stopped for a lack of subscribers.
+	CameraPaused
+)
+
+// float64Field is a float64 field for flyweights.
+type float64Field struct {
+	flyweight.Int64Field
+}
+
+func (f *float64Field) Get() float64 {
+	return math.Float64frombits(uint64(f.Int64Field.Get()))
+}
+
+func (f *float64Field) Set(v float64) {
+	f.Int64Field.Set(int64(math.Float64bits(v)))
+}
+
+// ControlCommandLength is the length of a ControlCommand. Fields unused by
+// a command are ignored.
+const ControlCommandLength = 40
+
+type ControlCommand struct {
+	flyweight.FWBase
+
+	Version       flyweight.Int32Field
+	Type          flyweight.Int32Field
+	CorrelationID flyweight.Int64Field
+	Value         float64Field
+	Width         flyweight.Int32Field
+	Height        flyweight.Int32Field
+	OffsetX       flyweight.Int32Field
+	OffsetY       flyweight.Int32Field
+}
+
+func (m *ControlCommand) Wrap(buf *atomic.Buffer, offset int) flyweight.Flyweight {
+	pos := offset
+	pos += m.Version.Wrap(buf, pos)
+	pos += m.Type.Wrap(buf, pos)
+	pos += m.CorrelationID.Wrap(buf, pos)
+	pos += m.Value.Wrap(buf, pos)
+	pos += m.Width.Wrap(buf, pos)
+	pos += m.Height.Wrap(buf, pos)
+	pos += m.OffsetX.Wrap(buf, pos)
+	pos += m.OffsetY.Wrap(buf, pos)
+	m.SetSize(pos - offset)
+	return m
+}
+
+const (
+	// controlResponseFixedLength is the length of the ControlResponse
+	// fields other than the message.
+	controlResponseFixedLength = 84
+	// MaxControlMessageLength bounds the error message of a response.
+	MaxControlMessageLength = 256
+	// MaxControlResponseLength is the length of a ControlResponse with the
+	// longest message.
+	MaxControlResponseLength = controlResponseFixedLength + MaxControlMessageLength
+)
+
+type ControlResponse struct {
+	flyweight.FWBase
+
+	Version           flyweight.Int32Field
+	Type              flyweight.Int32Field
+	CorrelationID     flyweight.Int64Field
+	Result            flyweight.Int32Field
+	State             flyweight.Int32Field
+	ExposureTime      float64Field
+	FrameRate         float64Field
+	SensorTemperature float64Field
+	FramesReceived    flyweight.Int64Field
+	SequenceNumber    flyweight.Int64Field
+	Width             flyweight.Int32Field
+	Height            flyweight.Int32Field
+	OffsetX           flyweight.Int32Field
+	OffsetY           flyweight.Int32Field
+	MessageLength     flyweight.Int32Field
+	Message           flyweight.RawDataField
+}
+
+func (m *ControlResponse) Wrap(buf *atomic.Buffer, offset int) flyweight.Flyweight {
+	pos := offset
+	pos += m.Version.Wrap(buf, pos)
+	pos += m.Type.Wrap(buf, pos)
+	pos += m.CorrelationID.Wrap(buf, pos)
+	pos += m.Result.Wrap(buf, pos)
+	pos += m.State.Wrap(buf, pos)
+	pos += m.ExposureTime.Wrap(buf, pos)
+	pos += m.FrameRate.Wrap(buf, pos)
+	pos += m.SensorTemperature.Wrap(buf, pos)
+	pos += m.FramesReceived.Wrap(buf, pos)
+	pos += m.SequenceNumber.Wrap(buf, pos)
+	pos += m.Width.Wrap(buf, pos)
+	pos += m.Height.Wrap(buf, pos)
+	pos += m.OffsetX.Wrap(buf, pos)
+	pos += m.OffsetY.Wrap(buf, pos)
+	pos += m.MessageLength.Wrap(buf, pos)
+	pos += m.Message.Wrap(buf, pos, m.MessageLength.Get())
+	m.SetSize(pos - offset)
+	return m
+}
+
+// ControlConfig configures the control and response streams.
+type ControlConfig struct {
+	Channel  string
+	StreamID int32
+	// ResponseChannel defaults to Channel.
+	ResponseChannel  string
+	ResponseStreamID int32
+}
+
+const (
+	// controlFragmentLimit is the number of fragments handled per poll.
+	controlFragmentLimit = 10
+	// controlMaxParkNs bounds the latency the idle poller adds to a command.
+	controlMaxParkNs = int64(time.Millisecond)
+	// responseTimeout bounds retrying a back pressured response.
+	responseTimeout = 10 * time.Millisecond
+)
+
+// responsePublication is the publication control responses are offered
+// to.
+type responsePublication interface {
+	Offer(buffer *atomic.Buffer, offset int32, length int32,
+		reservedValueSupplier term.ReservedValueSupplier) int64
+	Close() error
+}
+
+// Control executes the camera commands received on an Aeron stream. It
+// is a lighter alternative to the HTTP API for real-time controllers.
+// Commands are executed one at a time in the order they are received.
+type Control struct {
+	lg     *zap.Logger
+	cam    *FLICamera
+	config ControlConfig
+
+	// mu guards the subscription and publication, which are only used
+	// while the client that added them is connected
+	mu           sync.Mutex
+	subscription Subscription
+	response     responsePublication
+
+	assembler      *aeron.FragmentAssembler
+	command        ControlCommand
+	responseBuffer *atomic.Buffer
+	reply          ControlResponse
+}
+
+// NewControl returns the control of cam on the streams of config, served
+// once allocated.
+func NewControl(lg *zap.Logger, cam *FLICamera, config ControlConfig) *Control {
+	if config.ResponseChannel == "" {
+		config.ResponseChannel = config.Channel
+	}
+	c := &Control{
+		lg:             lg.Named("control"),
+		cam:            cam,
+		config:         config,
+		responseBuffer: atomic.MakeBuffer(make([]byte, MaxControlResponseLength)),
+	}
+	c.assembler = aeron.NewFragmentAssembler(c.onCommand, ControlCommandLength)
+	return c
+}
+
+// Allocate adds the control subscription and response publication with
+// client, replacing those of a previous client. It is an OnConnect hook of
+// the Transport.
+func (c *Control) Allocate(client *aeron.Aeron) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.close()
+	subscription, err := client.AddSubscription(c.config.Channel, c.config.StreamID)
+	if err != nil {
+		return fmt.Errorf("control subscription: %w", err)
+	}
+	response, err := client.AddExclusivePublication(c.config.ResponseChannel, c.config.ResponseStreamID)
+	if err != nil {
+		subscription.Close()
+		return fmt.Errorf("control response publication: %w", err)
+	}
+	c.attach(subscription, response)
+	return nil
+}
+
+// attach sets the subscription and response publication. c.mu must be
+// held.
+func (c *Control) attach(subscription Subscription, response responsePublication) {
+	c.subscription = subscription
+	c.response = response
+	c.assembler.Clear()
+}
+
+// Release forgets the subscription and publication after the client lost
+// the media driver. It is an OnDisconnect hook of the Transport.
+func (c *Control) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscription = nil
+	c.response = nil
+}
+
+// close closes the subscription and publication. c.mu must be held.
+func (c *Control) close() {
+	if c.subscription != nil {
+		if err := c.subscription.Close(); err != nil {
+			c.lg.Warn("Close control subscription", zap.Error(err))
+		}
+		c.subscription = nil
+	}
+	if c.response != nil {
+		if err := c.response.Close(); err != nil {
+			c.lg.Warn("Close control response publication", zap.Error(err))
+		}
+		c.response = nil
+	}
+}
+
+// Run polls for commands until ctx is done, then closes the subscription
+// and publication.
+func (c *Control) Run(ctx context.Context) error {
+	idle := idlestrategy.NewBackoffIdleStrategy(idlestrategy.DefaultMaxSpins,
+		idlestrategy.DefaultMaxYields, idlestrategy.DefaultMinParkNs, controlMaxParkNs)
+	for {
+		select {
+		case <-ctx.Done():
+			c.mu.Lock()
+			c.close()
+			c.mu.Unlock()
+			return nil
+		default:
+		}
+		idle.Idle(c.poll())
+	}
+}
+
+// poll executes the commands received and returns the number of fragments
+// handled.
+func (c *Control) poll() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subscription == nil {
+		return 0
+	}
+	return c.subscription.Poll(c.assembler.OnFragment, controlFragmentLimit)
+}
+
+// onCommand executes a command and publishes the response. c.mu is held.
+func (c *Control) onCommand(buf *atomic.Buffer, offset, length int32, _ *logbuffer.Header) {
+	var (
+		typ           CommandType
+		correlationID int64
+		err           error
+		result        = ResultOK
+	)
+	if length < ControlCommandLength {
+		result = ResultMalformed
+		err = fmt.Errorf("%d byte command, expected %d", length, ControlCommandLength)
+	} else {
+		c.command.Wrap(buf, int(offset))
+		typ = CommandType(c.command.Type.Get())
+		correlationID = c.command.CorrelationID.Get()
+		if v := c.command.Version.Get(); v != ControlVersion {
+			result = ResultMalformed
+			err = fmt.Errorf("command version %d, expected %d", v, ControlVersion)
+		} else {
+			result, err = c.execute(typ)
+		}
+	}
+
+	if result == ResultOK {
+		c.lg.Debug("Command", zap.Stringer("type", typ), zap.Int64("correlationId", correlationID))
+	} else {
+		c.lg.Warn("Command failed",
+			zap.Stringer("type", typ),
+			zap.Int64("correlationId", correlationID),
+			zap.Stringer("result", result),
+			zap.Error(err),
+		)
+	}
+	c.respond(typ, correlationID, result, err)
+}
+
+// execute applies the wrapped command to the camera.
+func (c *Control) execute(typ CommandType) (ResultCode, error) {
+	var err error
+	switch typ {
+	case CommandStatus:
+	case CommandStart:
+		err = c.cam.StartCamera()
+	case CommandStop:
+		err = c.cam.StopCamera()
+	case CommandSetExposure:
+		var v float64
+		if v, err = c.value(); err == nil {
+			_, err = c.cam.SetIntegrationTime(v)
+		}
+	case CommandSetFrameRate:
+		var v float64
+		if v, err = c.value(); err == nil {
+			_, err = c.cam.SetFrameRate(v)
+		}
+	case CommandSetROI:
+		width, height := c.command.Width.Get(), c.command.Height.Get()
+		offsetX, offsetY := c.command.OffsetX.Get(), c.command.OffsetY.Get()
+		if width <= 0 || height <= 0 || offsetX < 0 || offsetX > math.MaxUint16 ||
+			offsetY < 0 || offsetY > math.MaxUint16 {
+			return ResultOutOfRange, fmt.Errorf("%w: region of interest %dx%d+%d+%d",
+				ErrOutOfRange, width, height, offsetX, offsetY)
+		}
+		_, err = c.cam.SetROI(uint32(width), uint32(height), uint16(offsetX), uint16(offsetY))
+	default:
+		return ResultUnknownCommand, fmt.Errorf("unknown command %d", int32(typ))
+	}
+	return resultCode(err), err
+}
+
+// value returns the Value of the wrapped command, which must be finite.
+func (c *Control) value() (float64, error) {
+	v := c.command.Value.Get()
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%w: %v", ErrOutOfRange, v)
+	}
+	return v, nil
+}
+
+// respond publishes the response to a command with the camera status.
+func (c *Control) respond(typ CommandType, correlationID int64, result ResultCode, err error) {
+	if c.response == nil {
+		return
+	}
+	var message []byte
+	if err != nil {
+		message = []byte(err.Error())
+		if len(message) > MaxControlMessageLength {
+			message = message[:MaxControlMessageLength]
+		}
+	}
+
+	r := &c.reply
+	r.MessageLength.Wrap(c.responseBuffer, controlResponseFixedLength-4)
+	r.MessageLength.Set(int32(len(message)))
+	r.Wrap(c.responseBuffer, 0)
+	if len(message) > 0 {
+		r.Message.Get().PutBytesArray(0, &message, 0, int32(len(message)))
+	}
+	r.Version.Set(ControlVersion)
+	r.Type.Set(int32(typ))
+	r.CorrelationID.Set(correlationID)
+	r.Result.Set(int32(result))
+	c.status(r)
+
+	start := time.Now()
+	for {
+		ret := c.response.Offer(c.responseBuffer, 0, int32(r.Size()), nil)
+		switch ret {
+		case aeron.BackPressured, aeron.AdminAction:
+			if time.Since(start) < responseTimeout {
+				continue
+			}
+		}
+		if ret < 0 && ret != aeron.NotConnected {
+			c.lg.Warn("Response dropped",
+				zap.Int64("correlationId", correlationID),
+				zap.Stringer("result", offerResult(ret)),
+			)
+		}
+		return
+	}
+}
+
+// status writes the camera status to r. Unknown values are NaN.
+func (c *Control) status(r *ControlResponse) {
+	state := CameraStopped
+	if c.cam.Paused() {
+		state = CameraPaused
+	} else if c.cam.Acquiring() {
+		state = CameraAcquiring
+	}
+	r.State.Set(int32(state))
+
+	value := func(v LimitedValue, err error) float64 {
+		if err != nil {
+			return math.NaN()
+		}
+		return v.Value
+	}
+	r.ExposureTime.Set(value(c.cam.IntegrationTime()))
+	r.FrameRate.Set(value(c.cam.FrameRate()))
+	r.SensorTemperature.Set(c.cam.metadata.sensorTemperature.Load())
+
+	r.FramesReceived.Set(int64(c.cam.stats.framesReceived.Load()))
+	r.SequenceNumber.Set(c.cam.stats.sequenceNumber.Load())
+
+	g := c.cam.Geometry()
+	r.Width.Set(g.Width)
+	r.Height.Set(g.Height)
+	r.OffsetX.Set(g.OffsetX)
+	r.OffsetY.Set(g.OffsetY)
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"math"
+	"testing"
+
+	"github.com/lirm/aeron-go/aeron/atomic"
+	"github.com/lirm/aeron-go/aeron/logbuffer/term"
+	"go.uber.org/zap"
+)
+
+// exposureBackend is a benchBackend whose exposure settings the commands
+// change.
+type exposureBackend struct {
+	benchBackend
+	fps, tint float64
+	sets      int
+}
+
+func (b *exposureBackend) FrameRate() (LimitedValue, error) {
+	return LimitedValue{Value: b.fps, Min: 1, Max: 1000}, nil
+}
+
+func (b *exposureBackend) SetFrameRate(fps float64) (LimitedValue, error) {
+	b.sets++
+	b.fps = fps
+	return b.FrameRate()
+}
+
+func (b *exposureBackend) IntegrationTime() (LimitedValue, error) {
+	return LimitedValue{Value: b.tint, Min: 1e-6, Max: 1}, nil
+}
+
+func (b *exposureBackend) SetIntegrationTime(seconds float64) (LimitedValue, error) {
+	b.sets++
+	b.tint = seconds
+	return b.IntegrationTime()
+}
+
+// captureResponses keeps the control responses offered to it.
+type captureResponses struct {
+	messages [][]byte
+}
+
+func (p *captureResponses) Offer(buffer *atomic.Buffer, offset int32, length int32,
+	_ term.ReservedValueSupplier) int64 {
+	p.messages = append(p.messages, append([]byte(nil), buffer.GetBytesArray(offset, length)...))
+	return int64(len(p.messages))
+}
+
+func (p *captureResponses) Close() error {
+	return nil
+}
+
+func commandMessage(typ CommandType, correlationID int64, value float64) []byte {
+	m := make([]byte, ControlCommandLength)
+	var cmd ControlCommand
+	cmd.Wrap(atomic.MakeBuffer(m), 0)
+	cmd.Version.Set(ControlVersion)
+	cmd.Type.Set(int32(typ))
+	cmd.CorrelationID.Set(correlationID)
+	cmd.Value.Set(value)
+	return m
+}
+
+func TestControlRejectsNonFiniteValues(t *testing.T) {
+	output, err := NewOutput(zap.NewNop(), OutputConfig{
+		Name:    "live",
+		Channel: "aeron:ipc",
+	}, &benchPublication{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &exposureBackend{fps: 100, tint: 1e-3}
+	cam, err := NewFliCamera(zap.NewNop(), FliConfig{
+		Width:        16,
+		Height:       16,
+		SerialNumber: "test",
+	}, backend, []*Output{output})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commands := []struct {
+		typ   CommandType
+		value float64
+		want  ResultCode
+	}{
+		{CommandSetFrameRate, math.NaN(), ResultOutOfRange},
+		{CommandSetFrameRate, math.Inf(1), ResultOutOfRange},
+		{CommandSetExposure, math.NaN(), ResultOutOfRange},
+		{CommandSetExposure, math.Inf(-1), ResultOutOfRange},
+		{CommandSetFrameRate, 200, ResultOK},
+	}
+	subscription := new(standInSubscription)
+	for i, c := range commands {
+		subscription.messages = append(subscription.messages, commandMessage(c.typ, int64(i), c.value))
+	}
+	responses := new(captureResponses)
+	c := NewControl(zap.NewNop(), cam, ControlConfig{})
+	c.attach(subscription, responses)
+	for c.poll() > 0 {
+	}
+
+	if len(responses.messages) != len(commands) {
+		t.Fatalf("%d responses to %d commands", len(responses.messages), len(commands))
+	}
+	for i, m := range responses.messages {
+		var r ControlResponse
+		r.Wrap(atomic.MakeBuffer(m), 0)
+		if r.CorrelationID.Get() != int64(i) || ResultCode(r.Result.Get()) != commands[i].want {
+			t.Errorf("response %d to %v %v: correlation id %d, result %v, want %v", i,
+				commands[i].typ, commands[i].value, r.CorrelationID.Get(),
+				ResultCode(r.Result.Get()), commands[i].want)
+		}
+	}
+	if backend.sets != 1 || backend.fps != 200 || backend.tint != 1e-3 {
+		t.Errorf("backend set %d times to %v Hz and %v s, want once to 200 Hz", backend.sets, backend.fps, backend.tint)
+	}
+}
@@ -1,40 +1,66 @@
 package app
 
-/*
-extern void imageReceived(void*, void*);
-*/
-import "C"
 import (
 	"context"
 	"fmt"
-	"runtime/cgo"
-	"strings"
+	"math"
+	"runtime"
+	"sync"
+	syncatomic "sync/atomic"
 	"time"
 	"unsafe"
 
-	"github.com/New-Earth-Lab/flisdk-go/flisdk"
-	"github.com/lirm/aeron-go/aeron"
 	"github.com/lirm/aeron-go/aeron/atomic"
-	"github.com/lirm/aeron-go/aeron/flyweight"
-	"github.com/lirm/aeron-go/aeron/util"
+	"github.com/lirm/aeron-go/aeron/idlestrategy"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/New-Earth-Lab/flicameraservice/pkg/framecodec"
 )
 
 type FLICamera struct {
-	callbackHandler    flisdk.CallbackHandler
-	sdk                *flisdk.FliSdk
-	publication        *aeron.Publication
-	imageBuffer        *atomic.Buffer
-	headerBuffer       *atomic.Buffer
-	metadataBuffer     *atomic.Buffer
-	handle             cgo.Handle
-	header             ImageHeader
-	headerBufferLength int
-}
-
-const (
-	RingBufferNumImages = 4
-)
+	lg           *zap.Logger
+	backend      CameraBackend
+	outputs      []*Output
+	serialNumber string
+	model        string
+	stats        pipelineStats
+	callback     Observer
+	metadata     frameMetadata
+	// abandon ends the retries of PolicyBlock while positive: on shutdown
+	// and while the queue is drained for a new geometry
+	abandon syncatomic.Int32
+
+	// headerBuffer holds the frame message up to the image data, which is
+	// offered from the queue slot after it. headerLength is its length.
+	headerBuffer  *atomic.Buffer
+	messageHeader framecodec.MessageHeader
+	frame         framecodec.ImageFrame
+	headerLength  int32
+
+	// snapshots wait for the next published frames
+	snapshots snapshotRequests
+
+	// Frames are copied from the camera callback into the queue and
+	// published from a dedicated goroutine, so that a slow subscriber does
+	// not stall the grabber.
+	queue            *frameQueue
+	publisherCPUs    []int
+	publisherRunning syncatomic.Bool
+
+	// Frame callback state
+	sequence    int64
+	lastCounter uint64
+	haveCounter bool
+
+	mu        sync.Mutex
+	config    FliConfig
+	geometry  Geometry
+	acquiring bool
+	// paused is set while acquisition is stopped for a lack of
+	// subscribers. acquiring stays set.
+	paused bool
+}
 
 type FliConfig struct {
 	Width        uint32
@@ -42,144 +68,511 @@ type FliConfig struct {
 	OffsetX      uint16
 	OffsetY      uint16
 	SerialNumber string
+	// QueueLength is the number of frames buffered between the camera
+	// callback and the publisher, rounded up to a power of two.
+	QueueLength int
+	// PublisherCPUs pins the publisher thread to these CPUs if not empty.
+	// It is ignored outside Linux.
+	PublisherCPUs []int
+	// PauseWithoutSubscribers stops acquisition while no output has
+	// subscribers.
+	PauseWithoutSubscribers bool
 }
 
-func NewFliCamera(config FliConfig, publication *aeron.Publication) (*FLICamera, error) {
-	// Get the configuration for the camera
-	// Grabber name
-	// Camera name
-	// ROI - cropping mode
-	// Gain
+// DefaultQueueLength buffers frames for short publication stalls.
+const DefaultQueueLength = 8
+
+// MaxImageHeaderLength is the length of a frame message up to its image
+// data, with MaxMetadataLength bytes of metadata.
+const MaxImageHeaderLength = framecodec.MessageHeaderEncodedLength +
+	framecodec.ImageFrameBlockLength +
+	framecodec.ImageFrameMetadataHeaderLength + MaxMetadataLength +
+	framecodec.ImageFrameImageHeaderLength
+
+// NewFliCamera configures the camera and publishes its frames to outputs.
+func NewFliCamera(lg *zap.Logger, config FliConfig, backend CameraBackend,
+	outputs []*Output) (*FLICamera, error) {
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("flicamera: no outputs")
+	}
+	names := make(map[string]bool, len(outputs))
+	for _, o := range outputs {
+		if names[o.config.Name] {
+			return nil, fmt.Errorf("flicamera: duplicate output name: %s", o.config.Name)
+		}
+		names[o.config.Name] = true
+	}
 
-	var err error
-	sdk, err := flisdk.Init()
+	model, err := backend.Detect(config.SerialNumber)
 	if err != nil {
 		return nil, err
 	}
-	defer func(e *error) {
-		if *e != nil {
-			sdk.Exit()
-		}
-	}(&err)
 
-	// Get list of grabbers
-	_, err = sdk.DetectGrabbers()
-	if err != nil {
+	if err := checkROI(backend, config); err != nil {
 		return nil, err
 	}
 
-	// Get list of cameras
-	cameraStrings, err := sdk.DetectCameras()
+	geometry, err := backend.Configure(config)
 	if err != nil {
 		return nil, err
 	}
 
-	found := false
-	for _, cam := range cameraStrings {
-		if strings.Contains(cam, config.SerialNumber) {
-			err = sdk.SetCamera(cam)
-			if err != nil {
-				return nil, err
-			}
-			found = true
-			break
-		}
+	queueLength := config.QueueLength
+	if queueLength <= 0 {
+		queueLength = DefaultQueueLength
 	}
 
-	// Set the camera to the configured model if found
-	if !found {
-		return nil, fmt.Errorf("flicamera: Unable to find camera: %s", config.SerialNumber)
+	header := make([]byte, MaxImageHeaderLength)
+	cam := FLICamera{
+		lg:            lg,
+		backend:       backend,
+		outputs:       outputs,
+		headerBuffer:  atomic.MakeBuffer(header),
+		serialNumber:  config.SerialNumber,
+		model:         model,
+		callback:      nopObserver{},
+		queue:         newFrameQueue(queueLength),
+		publisherCPUs: config.PublisherCPUs,
+		config:        config,
 	}
 
-	err = sdk.SetMode(flisdk.Mode_Full)
+	// Set static header information
+	cam.frame.WrapAndApplyHeader(cam.headerBuffer, 0, &cam.messageHeader)
+	cam.frame.SetPayloadType(framecodec.PayloadTypeImage)
+	cam.frame.SetFormat(framecodec.PixelFormatMono16)
+	cam.frame.SetPaddingX(0)
+	cam.frame.SetPaddingY(0)
+
+	// Lay out the metadata, then encode its actual length
+	readoutMode := ""
+	if r, ok := backend.(ReadoutModeReporter); ok {
+		readoutMode = r.ReadoutMode()
+	}
+	offset := cam.frame.PutMetadataLength(uint32(MaxMetadataLength))
+	n, err := cam.metadata.layout(atomic.MakeBuffer(header[offset:cam.frame.Limit()]),
+		config.SerialNumber, readoutMode)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("flicamera: %w", err)
 	}
+	cam.frame.WrapForEncode(cam.headerBuffer, framecodec.MessageHeaderEncodedLength)
+	cam.frame.PutMetadataLength(uint32(n))
+	cam.headerLength = cam.frame.Limit() + framecodec.ImageFrameImageHeaderLength
 
-	err = sdk.Update()
-	if err != nil {
-		return nil, err
+	cam.setGeometry(geometry)
+	if err := cam.checkMessageLength(geometry); err != nil {
+		return nil, fmt.Errorf("flicamera: %w", err)
 	}
+	cam.refreshMetadata()
+
+	backend.SetFrameHandler(cam.imageReceived)
 
-	// Set sensor cropping
-	croppingData := flisdk.CroppingData{
-		Col1:    config.OffsetX,
-		Col2:    config.OffsetX + uint16(config.Width) - 1,
-		Row1:    config.OffsetY,
-		Row2:    config.OffsetY + uint16(config.Height) - 1,
-		Enabled: true,
+	return &cam, nil
+}
+
+// setGeometry updates the geometry and the header fields derived from it.
+// Acquisition must be stopped.
+func (f *FLICamera) setGeometry(g Geometry) {
+	f.geometry = g
+	f.queue.resize(int(g.ImageSizeInBytes))
+	f.metadata.setCropWindow(g)
+	f.frame.SetSizeX(g.Width)
+	f.frame.SetSizeY(g.Height)
+	f.frame.SetOffsetX(g.OffsetX)
+	f.frame.SetOffsetY(g.OffsetY)
+	f.frame.SetLimit(f.headerLength - framecodec.ImageFrameImageHeaderLength)
+	f.frame.PutImageLength(uint32(g.ImageSizeInBytes))
+}
+
+// refreshMetadata reads the exposure settings and sensor temperature from
+// the backend for the frame metadata. Unknown values are NaN.
+func (f *FLICamera) refreshMetadata() {
+	f.metadata.exposureTime.Store(math.NaN())
+	f.metadata.frameRate.Store(math.NaN())
+	f.metadata.sensorTemperature.Store(math.NaN())
+	if ec, ok := f.backend.(ExposureController); ok {
+		if v, err := ec.IntegrationTime(); err == nil {
+			f.metadata.exposureTime.Store(v.Value)
+		}
+		if v, err := ec.FrameRate(); err == nil {
+			f.metadata.frameRate.Store(v.Value)
+		}
+	}
+	if tc, ok := f.backend.(ThermalController); ok {
+		if t, err := tc.Temperatures(); err == nil {
+			f.metadata.sensorTemperature.Store(t.Sensor)
+		}
 	}
+}
 
-	err = sdk.SetCroppingState(croppingData)
-	if err != nil {
-		return nil, err
+// SetROI stops acquisition, applies a new sensor cropping window and
+// restarts acquisition if it was running. The next published frame carries
+// the new geometry. Windows whose frames do not fit the max message length
+// of an output are rejected. On failure the previous window is restored.
+func (f *FLICamera) SetROI(width, height uint32, offsetX, offsetY uint16) (Geometry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	config := f.config
+	config.Width = width
+	config.Height = height
+	config.OffsetX = offsetX
+	config.OffsetY = offsetY
+
+	if err := checkROI(f.backend, config); err != nil {
+		return f.geometry, err
 	}
 
-	// Set the pixel format to unsigned
-	sdk.EnableUnsignedPixel(true)
+	running := f.acquiring && !f.paused
+	if running {
+		if err := f.backend.Stop(); err != nil {
+			return f.geometry, err
+		}
+	}
+	// The queued frames have the previous geometry
+	if err := f.drainQueue(); err != nil {
+		if running {
+			if serr := f.backend.Start(); serr != nil {
+				f.acquiring = false
+			}
+		}
+		return f.geometry, err
+	}
 
-	// Enable the ring buffer to shrink it
-	sdk.EnableRingBuffer(true)
-	sdk.SetBufferSizeInImages(RingBufferNumImages)
+	geometry, err := f.backend.Configure(config)
+	if err == nil {
+		err = f.checkMessageLength(geometry)
+	}
+	if err != nil {
+		// Restore the previous window
+		if g, rerr := f.backend.Configure(f.config); rerr == nil {
+			f.setGeometry(g)
+		} else {
+			err = fmt.Errorf("%w (restoring previous window: %v)", err, rerr)
+		}
+	} else {
+		f.config = config
+		f.setGeometry(geometry)
+	}
+	// Reconfiguring may change the exposure settings the camera applies
+	f.refreshMetadata()
+
+	if running {
+		if serr := f.backend.Start(); serr != nil {
+			f.acquiring = false
+			if err == nil {
+				err = serr
+			}
+		}
+	}
+	return f.geometry, err
+}
 
-	// Disable the ring buffer
-	sdk.EnableRingBuffer(false)
-	sdk.SetNumberImagesPerBuffer(1)
+// MessageLength returns the length of the frame messages of the current
+// geometry.
+func (f *FLICamera) MessageLength() int32 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 
-	// Get image dimensions for buffer size
-	width, height := sdk.GetCurrentImageDimension()
+	return f.headerLength + f.geometry.ImageSizeInBytes
+}
 
-	cam := FLICamera{
-		sdk:          sdk,
-		imageBuffer:  new(atomic.Buffer),
-		publication:  publication,
-		headerBuffer: atomic.MakeBuffer(make([]byte, 256)), // TODO: this is wasteful
+// checkMessageLength checks that frames of geometry g fit the max message
+// length of every output.
+func (f *FLICamera) checkMessageLength(g Geometry) error {
+	length := f.headerLength + g.ImageSizeInBytes
+	for _, o := range f.outputs {
+		if err := o.checkMessageLength(length); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Set static header information
-	cam.header.Wrap(cam.headerBuffer, 0)
-	cam.header.Version.Set(0)
-	cam.header.PayloadType.Set(0)
-	cam.header.Format.Set(0x01100007) // Mono16
-	cam.header.SizeX.Set(int32(width))
-	cam.header.SizeY.Set(int32(height))
-	cam.header.OffsetX.Set(0)
-	cam.header.OffsetY.Set(0)
-	cam.header.PaddingX.Set(0)
-	cam.header.PaddingY.Set(0)
-	cam.header.MetadataLength.Set(0)
-	cam.header.ImageBufferLength.Set(int32(sdk.GetImageSizeInBytes()))
-
-	cam.callbackHandler = sdk.AddCallbackNewImage(
-		(flisdk.NewImageAvailableCallBack)(C.imageReceived),
-		0, true, cam)
-
-	return &cam, nil
+// checkROI rejects empty cropping windows and windows whose last row or
+// column does not fit the 16-bit sensor coordinates, or the sensor if the
+// backend reports its size.
+func checkROI(backend CameraBackend, c FliConfig) error {
+	if c.Width == 0 || c.Height == 0 {
+		return fmt.Errorf("%w: empty region of interest", ErrOutOfRange)
+	}
+	maxX, maxY := uint64(math.MaxUint16)+1, uint64(math.MaxUint16)+1
+	if r, ok := backend.(SensorReporter); ok {
+		w, h := r.SensorSize()
+		maxX, maxY = uint64(w), uint64(h)
+	}
+	if uint64(c.OffsetX)+uint64(c.Width) > maxX || uint64(c.OffsetY)+uint64(c.Height) > maxY {
+		return fmt.Errorf("%w: region of interest %dx%d+%d+%d outside %dx%d sensor",
+			ErrOutOfRange, c.Width, c.Height, c.OffsetX, c.OffsetY, maxX, maxY)
+	}
+	return nil
 }
 
 func (f *FLICamera) StartCamera() error {
-	return f.sdk.Start()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.acquiring {
+		return nil
+	}
+	// The backend image counter restarts with acquisition
+	f.haveCounter = false
+	err := f.backend.Start()
+	if err != nil {
+		return err
+	}
+	f.acquiring = true
+	return nil
 }
 
 func (f *FLICamera) StopCamera() error {
-	return f.sdk.Stop()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.acquiring {
+		return nil
+	}
+	if !f.paused {
+		if err := f.backend.Stop(); err != nil {
+			return err
+		}
+	}
+	f.acquiring = false
+	f.paused = false
+	return nil
 }
 
 func (f *FLICamera) Shutdown() error {
-	err := f.sdk.Stop()
+	f.abandon.Add(1)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.acquiring = false
+	f.paused = false
+	return f.backend.Shutdown()
+}
+
+// SerialNumber returns the serial number of the camera.
+func (f *FLICamera) SerialNumber() string {
+	return f.serialNumber
+}
+
+// Model returns the camera description reported by the backend.
+func (f *FLICamera) Model() string {
+	return f.model
+}
+
+// Acquiring reports whether the camera is acquiring frames.
+func (f *FLICamera) Acquiring() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.acquiring
+}
+
+// Paused reports whether acquisition is paused because no output has
+// subscribers.
+func (f *FLICamera) Paused() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.paused
+}
+
+// Geometry returns the current image geometry.
+func (f *FLICamera) Geometry() Geometry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.geometry
+}
+
+// FrameRate returns the camera frame rate in Hz.
+func (f *FLICamera) FrameRate() (LimitedValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ec, ok := f.backend.(ExposureController)
+	if !ok {
+		return LimitedValue{}, ErrNotSupported
+	}
+	return ec.FrameRate()
+}
+
+// SetFrameRate sets the camera frame rate in Hz and returns the accepted
+// value.
+func (f *FLICamera) SetFrameRate(fps float64) (LimitedValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ec, ok := f.backend.(ExposureController)
+	if !ok {
+		return LimitedValue{}, ErrNotSupported
+	}
+	current, err := ec.FrameRate()
 	if err != nil {
-		return err
+		return LimitedValue{}, err
 	}
+	if err := checkRange(fps, current); err != nil {
+		return current, err
+	}
+	v, err := ec.SetFrameRate(fps)
+	if err != nil {
+		return v, err
+	}
+	f.metadata.frameRate.Store(v.Value)
+	// The frame period may have limited the integration time
+	if tint, err := ec.IntegrationTime(); err == nil {
+		f.metadata.exposureTime.Store(tint.Value)
+	}
+	return v, nil
+}
 
-	f.sdk.RemoveCallbackNewImage(f.callbackHandler)
-	f.sdk.Exit()
+// IntegrationTime returns the camera integration time in seconds.
+func (f *FLICamera) IntegrationTime() (LimitedValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 
+	ec, ok := f.backend.(ExposureController)
+	if !ok {
+		return LimitedValue{}, ErrNotSupported
+	}
+	return ec.IntegrationTime()
+}
+
+// SetIntegrationTime sets the camera integration time in seconds and
+// returns the accepted value.
+func (f *FLICamera) SetIntegrationTime(seconds float64) (LimitedValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ec, ok := f.backend.(ExposureController)
+	if !ok {
+		return LimitedValue{}, ErrNotSupported
+	}
+	current, err := ec.IntegrationTime()
+	if err != nil {
+		return LimitedValue{}, err
+	}
+	if err := checkRange(seconds, current); err != nil {
+		return current, err
+	}
+	v, err := ec.SetIntegrationTime(seconds)
+	if err != nil {
+		return v, err
+	}
+	f.metadata.exposureTime.Store(v.Value)
+	return v, nil
+}
+
+// Temperatures returns the camera temperature readings.
+func (f *FLICamera) Temperatures() (Temperatures, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tc, ok := f.backend.(ThermalController)
+	if !ok {
+		return Temperatures{}, ErrNotSupported
+	}
+	t, err := tc.Temperatures()
+	if err != nil {
+		return t, err
+	}
+	f.metadata.sensorTemperature.Store(t.Sensor)
+	return t, nil
+}
+
+// SensorSetpoint returns the sensor cooling setpoint in degrees Celsius.
+func (f *FLICamera) SensorSetpoint() (LimitedValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tc, ok := f.backend.(ThermalController)
+	if !ok {
+		return LimitedValue{}, ErrNotSupported
+	}
+	return tc.SensorSetpoint()
+}
+
+// SetSensorSetpoint sets the sensor cooling setpoint in degrees Celsius and
+// returns the accepted value.
+func (f *FLICamera) SetSensorSetpoint(celsius float64) (LimitedValue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tc, ok := f.backend.(ThermalController)
+	if !ok {
+		return LimitedValue{}, ErrNotSupported
+	}
+	current, err := tc.SensorSetpoint()
+	if err != nil {
+		return LimitedValue{}, err
+	}
+	if err := checkRange(celsius, current); err != nil {
+		return current, err
+	}
+	return tc.SetSensorSetpoint(celsius)
+}
+
+func checkRange(v float64, limits LimitedValue) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v < limits.Min || v > limits.Max {
+		return fmt.Errorf("%w: %v not in [%v, %v]", ErrOutOfRange, v, limits.Min, limits.Max)
+	}
 	return nil
 }
 
+// Stats returns a snapshot of the frame pipeline counters.
+func (f *FLICamera) Stats() PipelineStats {
+	return f.stats.snapshot(f.outputs)
+}
+
+// Outputs returns the outputs frames are published to.
+func (f *FLICamera) Outputs() []*Output {
+	return f.outputs
+}
+
+// SetCallbackObserver sets the observer of the camera callback duration in
+// seconds. It must be called before acquisition starts.
+func (f *FLICamera) SetCallbackObserver(o Observer) {
+	f.callback = o
+}
+
+// QueueDepth returns the number of frames waiting to be published.
+func (f *FLICamera) QueueDepth() int {
+	return f.queue.len()
+}
+
+// QueueCapacity returns the number of frames the queue holds.
+func (f *FLICamera) QueueCapacity() int {
+	return f.queue.capacity()
+}
+
 func (f *FLICamera) Run(ctx context.Context) error {
 	wg, ctx := errgroup.WithContext(ctx)
 
+	wg.Go(func() error {
+		return f.runPublisher(ctx.Done())
+	})
+	for _, o := range f.outputs {
+		o := o
+		wg.Go(func() error {
+			return o.Run(ctx)
+		})
+	}
+	if f.config.PauseWithoutSubscribers {
+		wg.Go(func() error {
+			f.pauseWithoutSubscribers(subscriberCheckInterval, ctx.Done())
+			return nil
+		})
+	}
+	wg.Go(func() error {
+		f.stats.measureFrameRate(time.Second, ctx.Done())
+		return nil
+	})
+	wg.Go(func() error {
+		f.reportDrops(time.Second, ctx.Done())
+		return nil
+	})
 	wg.Go(func() error {
 		<-ctx.Done()
 		return f.Shutdown()
@@ -187,79 +580,208 @@ func (f *FLICamera) Run(ctx context.Context) error {
 	return wg.Wait()
 }
 
-type ImageHeader struct {
-	flyweight.FWBase
-
-	Version           flyweight.Int32Field
-	PayloadType       flyweight.Int32Field
-	TimestampNs       flyweight.Int64Field
-	Format            flyweight.Int32Field
-	SizeX             flyweight.Int32Field
-	SizeY             flyweight.Int32Field
-	OffsetX           flyweight.Int32Field
-	OffsetY           flyweight.Int32Field
-	PaddingX          flyweight.Int32Field
-	PaddingY          flyweight.Int32Field
-	MetadataLength    flyweight.Int32Field
-	MetadataBuffer    flyweight.RawDataField
-	pad0              flyweight.Padding
-	ImageBufferLength flyweight.Int32Field
-}
-
-func (m *ImageHeader) Wrap(buf *atomic.Buffer, offset int) flyweight.Flyweight {
-	pos := offset
-	pos += m.Version.Wrap(buf, pos)
-	pos += m.PayloadType.Wrap(buf, pos)
-	pos += m.TimestampNs.Wrap(buf, pos)
-	pos += m.Format.Wrap(buf, pos)
-	pos += m.SizeX.Wrap(buf, pos)
-	pos += m.SizeY.Wrap(buf, pos)
-	pos += m.OffsetX.Wrap(buf, pos)
-	pos += m.OffsetY.Wrap(buf, pos)
-	pos += m.PaddingX.Wrap(buf, pos)
-	pos += m.PaddingY.Wrap(buf, pos)
-	pos += m.MetadataLength.Wrap(buf, pos)
-	pos += m.MetadataBuffer.Wrap(buf, pos, 0)
-	pos = int(util.AlignInt32(int32(pos), 4))
-	pos += m.ImageBufferLength.Wrap(buf, pos)
-	m.SetSize(pos - offset)
-	return m
-}
-
-//export imageReceived
-//go:nocheckptr go:nosplit
-func imageReceived(image unsafe.Pointer, ctx unsafe.Pointer) {
-	cam := (cgo.Handle)(ctx).Value().(FLICamera)
+// subscriberCheckInterval is the interval at which the outputs are checked
+// for subscribers to pause acquisition.
+const subscriberCheckInterval = 100 * time.Millisecond
+
+// pauseWithoutSubscribers stops acquisition while no output has
+// subscribers and restarts it when one has, checking at every interval until
+// done is closed.
+func (f *FLICamera) pauseWithoutSubscribers(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		connected := false
+		for _, o := range f.outputs {
+			if o.Connected() {
+				connected = true
+				break
+			}
+		}
+		f.setPaused(!connected)
+	}
+}
+
+// setPaused pauses or resumes acquisition if it was started.
+func (f *FLICamera) setPaused(paused bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.acquiring || f.paused == paused {
+		return
+	}
+	var err error
+	if paused {
+		err = f.backend.Stop()
+	} else {
+		// The backend image counter restarts with acquisition
+		f.haveCounter = false
+		err = f.backend.Start()
+	}
+	if err != nil {
+		f.lg.Warn("Pausing acquisition failed", zap.Bool("paused", paused), zap.Error(err))
+		return
+	}
+	f.paused = paused
+	if paused {
+		f.lg.Info("Acquisition paused, no output has subscribers")
+	} else {
+		f.lg.Info("Acquisition resumed")
+	}
+}
+
+// reportDrops logs the frames dropped since the previous report at every
+// interval until done is closed.
+func (f *FLICamera) reportDrops(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := f.Stats()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		s := f.Stats()
+		if s.GrabberDropped != last.GrabberDropped || s.QueueOverruns != last.QueueOverruns {
+			f.lg.Warn("Frames dropped",
+				zap.Uint64("grabber", s.GrabberDropped-last.GrabberDropped),
+				zap.Uint64("queue", s.QueueOverruns-last.QueueOverruns),
+				zap.Uint64("grabberTotal", s.GrabberDropped),
+				zap.Uint64("queueTotal", s.QueueOverruns),
+				zap.Int64("sequenceNumber", s.SequenceNumber),
+			)
+		}
+		for i, o := range s.Outputs {
+			dropped := o.PublicationDropped - last.Outputs[i].PublicationDropped
+			if dropped == 0 {
+				continue
+			}
+			f.lg.Warn("Frames dropped",
+				zap.String("output", o.Config.Name),
+				zap.Uint64("publication", dropped),
+				zap.Uint64("publicationTotal", o.PublicationDropped),
+				zap.Int64("sequenceNumber", s.SequenceNumber),
+			)
+		}
+		last = s
+	}
+}
+
+// imageReceived queues a frame delivered by the camera backend. It only
+// copies the frame; a full queue drops it.
+func (f *FLICamera) imageReceived(image unsafe.Pointer, counter uint64) {
 	start := time.Now()
+	f.stats.framesReceived.Add(1)
+
+	// Frames missing from the backend counter were dropped at the grabber
+	if f.haveCounter && counter > f.lastCounter+1 {
+		f.stats.grabberDropped.Add(counter - f.lastCounter - 1)
+	}
+	f.lastCounter = counter
+	f.haveCounter = true
+
+	// Dropped frames keep their sequence number, leaving a gap
+	sequence := f.sequence
+	f.sequence++
+	f.stats.sequenceNumber.Store(sequence)
+
+	slot := f.queue.claim()
+	if slot == nil {
+		f.stats.queueOverruns.Add(1)
+		f.callback.Observe(time.Since(start).Seconds())
+		return
+	}
+	copy(slot.data, unsafe.Slice((*byte)(image), len(slot.data)))
+	slot.received = start
+	slot.monotonicNs = monotonicNs()
+	slot.sequence = sequence
+	f.queue.commit()
+
+	f.callback.Observe(time.Since(start).Seconds())
+}
 
-	// Get image dimensions for buffer size
-	// width, height, bytes := cam.sdk.GetImageSize()
-	// crop, _ := cam.sdk.GetCroppingState()
-
-	// Set
-	cam.header.TimestampNs.Set(start.UnixNano())
-	// cam.header.SizeX.Set(int32(width))
-	// cam.header.SizeY.Set(int32(height))
-	// cam.header.OffsetX.Set(int32(crop.Col1))
-	// cam.header.OffsetY.Set(int32(crop.Row1))
-	// cam.header.ImageBufferLength.Set(int32(bytes))
-
-	cam.imageBuffer.Wrap(image, cam.header.ImageBufferLength.Get())
-
-	const timeout = 100 * time.Microsecond
-
-	for time.Since(start) < timeout {
-		ret := cam.publication.Offer2(cam.headerBuffer, 0,
-			int32(cam.header.Size()), cam.imageBuffer, 0,
-			cam.imageBuffer.Capacity(), nil)
-		switch ret {
-		// Retry on AdminAction and BackPressured
-		case aeron.AdminAction, aeron.BackPressured:
+// runPublisher publishes the queued frames until done is closed. It keeps
+// its goroutine on one OS thread, pinned to the configured CPUs.
+func (f *FLICamera) runPublisher(done <-chan struct{}) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if len(f.publisherCPUs) > 0 {
+		if err := pinThread(f.publisherCPUs); err != nil {
+			return fmt.Errorf("flicamera: pin publisher to CPUs %v: %w", f.publisherCPUs, err)
+		}
+	}
+
+	f.publisherRunning.Store(true)
+	defer f.publisherRunning.Store(false)
+
+	idle := idlestrategy.NewBackoffIdleStrategy(idlestrategy.DefaultMaxSpins,
+		idlestrategy.DefaultMaxYields, idlestrategy.DefaultMinParkNs, publisherMaxParkNs)
+	for {
+		slot := f.queue.peek()
+		if slot == nil {
+			select {
+			case <-done:
+				return nil
+			default:
+			}
+			idle.Idle(0)
 			continue
-		// Otherwise return as completed
-		default:
-			return
 		}
+		f.publishFrame(slot)
+		f.queue.release()
+		idle.Idle(1)
+	}
+}
+
+// publisherMaxParkNs is the longest the idle publisher parks. Parking
+// sleeps, which lasts about a millisecond on Linux whatever is asked for,
+// so frames may wait that long in the queue before they are offered.
+const publisherMaxParkNs = int64(50 * time.Microsecond)
+
+// drainTimeout bounds waiting for the publisher to drain the queue.
+const drainTimeout = time.Second
+
+// drainQueue waits until the publisher has handled the queued frames, or
+// discards them if it is not running. Blocked offers are abandoned
+// meanwhile, so that a stalled subscriber does not hold up the drain.
+// Acquisition must be stopped.
+func (f *FLICamera) drainQueue() error {
+	f.abandon.Add(1)
+	defer f.abandon.Add(-1)
+
+	deadline := time.Now().Add(drainTimeout)
+	for f.queue.len() > 0 && f.publisherRunning.Load() {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("flicamera: publisher did not drain %d queued frames in %v",
+				f.queue.len(), drainTimeout)
+		}
+		time.Sleep(100 * time.Microsecond)
+	}
+	f.queue.clear()
+	return nil
+}
+
+// publishFrame offers a queued frame to the outputs it is due on.
+func (f *FLICamera) publishFrame(slot *frameSlot) {
+	f.frame.SetTimestampNs(slot.received.UnixNano())
+	f.frame.SetSequenceNumber(slot.sequence)
+	f.metadata.update(slot.received.UnixNano(), slot.monotonicNs)
+
+	for _, o := range f.outputs {
+		if o.wants(slot.sequence) {
+			o.offer(f.headerBuffer, f.headerLength, slot, &f.abandon)
+		}
+	}
+	if f.snapshots.pending.Load() {
+		f.captureSnapshots(slot)
 	}
 }
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/atomic"
+	"github.com/lirm/aeron-go/aeron/logbuffer/term"
+	"go.uber.org/zap"
+)
+
+const (
+	benchWidth  = 640
+	benchHeight = 512
+	// benchFramePeriod paces the frames like a camera running at 1 kHz.
+	benchFramePeriod = time.Millisecond
+)
+
+// benchBackend is a CameraBackend whose frames are delivered by the
+// benchmark calling the frame handler directly.
+type benchBackend struct{}
+
+func (benchBackend) Detect(string) (string, error) { return "bench", nil }
+
+func (benchBackend) Configure(config FliConfig) (Geometry, error) {
+	return Geometry{
+		Width:            int32(config.Width),
+		Height:           int32(config.Height),
+		ImageSizeInBytes: int32(config.Width * config.Height * 2),
+	}, nil
+}
+
+func (benchBackend) SetFrameHandler(FrameHandler) {}
+func (benchBackend) Start() error                 { return nil }
+func (benchBackend) Stop() error                  { return nil }
+func (benchBackend) Shutdown() error              { return nil }
+
+// benchPublication accepts every frame, or none if backPressured is set.
+type benchPublication struct {
+	backPressured bool
+}
+
+func (p *benchPublication) Offer2(_ *atomic.Buffer, _ int32, _ int32,
+	_ *atomic.Buffer, _ int32, _ int32, _ term.ReservedValueSupplier) int64 {
+	if p.backPressured {
+		return aeron.BackPressured
+	}
+	return 1
+}
+
+// sampleObserver keeps the samples to report their distribution.
+type sampleObserver struct {
+	samples []float64
+}
+
+func (o *sampleObserver) Observe(v float64) {
+	o.samples = append(o.samples, v)
+}
+
+// quantile returns the q quantile of the samples in microseconds.
+func (o *sampleObserver) quantile(q float64) float64 {
+	if len(o.samples) == 0 {
+		return 0
+	}
+	sort.Float64s(o.samples)
+	return o.samples[int(q*float64(len(o.samples)-1))] * 1e6
+}
+
+// BenchmarkImageReceived measures the camera callback while the publisher
+// goroutine offers to a subscriber that keeps up or is back pressured. The
+// callback only copies the frame into the queue, or drops it when the queue
+// is full, so its duration does not grow with subscriber back pressure.
+// The maximum includes the occasional descheduling of the benchmark, the
+// 99th percentile is the figure to compare.
+//
+// Frames are paced like a camera delivers them. A benchmark that catches up
+// with its schedule after oversleeping sends bursts of frames faster than
+// the idle publisher wakes up, which overflow the queue even when the
+// subscriber keeps up.
+func BenchmarkImageReceived(b *testing.B) {
+	for _, bc := range []struct {
+		name          string
+		backPressured bool
+		policy        BackPressurePolicy
+	}{
+		{"accepted", false, PolicySpin},
+		{"backpressured/drop-newest", true, PolicyDropNewest},
+		{"backpressured/spin", true, PolicySpin},
+		{"backpressured/block", true, PolicyBlock},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			output, err := NewOutput(zap.NewNop(), OutputConfig{
+				Name:    "bench",
+				Channel: "aeron:ipc",
+				Publish: PublishConfig{
+					Policy:  bc.policy,
+					Timeout: DefaultPublishConfig.Timeout,
+				},
+			}, &benchPublication{backPressured: bc.backPressured}, nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			cam, err := NewFliCamera(zap.NewNop(), FliConfig{
+				Width:        benchWidth,
+				Height:       benchHeight,
+				SerialNumber: "bench",
+			}, benchBackend{}, []*Output{output})
+			if err != nil {
+				b.Fatal(err)
+			}
+			callback := &sampleObserver{samples: make([]float64, 0, b.N)}
+			cam.SetCallbackObserver(callback)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- cam.runPublisher(ctx.Done())
+			}()
+
+			image := make([]uint16, benchWidth*benchHeight)
+			b.SetBytes(int64(len(image) * 2))
+			b.ResetTimer()
+			next := time.Now()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				// Sleeping overshoots by up to milliseconds; the frames
+				// that would then catch up with the schedule are spaced
+				// by the frame period instead, like a camera delivers them.
+				next = next.Add(benchFramePeriod)
+				if now := time.Now(); next.Before(now) {
+					next = now
+				}
+				time.Sleep(time.Until(next))
+				b.StartTimer()
+				cam.imageReceived(unsafe.Pointer(&image[0]), uint64(i))
+			}
+			b.StopTimer()
+
+			cam.abandon.Add(1)
+			cancel()
+			if err := <-done; err != nil {
+				b.Fatal(err)
+			}
+			s := cam.Stats()
+			b.ReportMetric(callback.quantile(0.99), "p99-µs")
+			b.ReportMetric(callback.quantile(1), "max-µs")
+			b.ReportMetric(float64(s.QueueOverruns)/float64(b.N), "overruns/op")
+		})
+	}
+}
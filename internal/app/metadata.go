@@ -0,0 +1,161 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lirm/aeron-go/aeron/atomic"
+)
+
+// Frame metadata is written to the metadata field of the ImageFrame as a
+// versioned list of typed key/value entries. All fields are little-endian
+// and every entry starts on an 8-byte boundary of the field:
+//
+//	version  uint16
+//	count    uint16
+//	reserved uint32
+//	count × {
+//		key    uint16
+//		type   uint16
+//		length uint32          // value length in bytes, without padding
+//		value  [length]byte    // zero padded to a multiple of 8 bytes
+//	}
+//
+// Subscribers must skip entries with unknown keys or types.
+const MetadataVersion = 1
+
+// MetadataKey identifies a metadata entry.
+type MetadataKey uint16
+
+const (
+	// MetadataExposureTime is the integration time in seconds (float64).
+	MetadataExposureTime MetadataKey = iota + 1
+	// MetadataFrameRate is the frame rate in Hz (float64).
+	MetadataFrameRate
+	// MetadataSensorTemperature is in degrees Celsius (float64).
+	MetadataSensorTemperature
+	// MetadataReadoutMode is the camera readout mode (string).
+	MetadataReadoutMode
+	// MetadataSerialNumber is the camera serial number (string).
+	MetadataSerialNumber
+	// MetadataCropWindow is offset x, offset y, width and height on the
+	// sensor (int32 array).
+	MetadataCropWindow
+	// MetadataRealtimeNs is the host CLOCK_REALTIME in ns (int64).
+	MetadataRealtimeNs
+	// MetadataMonotonicNs is the host CLOCK_MONOTONIC in ns (int64), or
+	// the time since the service started where there is none.
+	MetadataMonotonicNs
+	numMetadataKeys
+)
+
+// MetadataType is the encoding of a metadata value.
+type MetadataType uint16
+
+const (
+	MetadataFloat64 MetadataType = iota + 1
+	MetadataInt64
+	MetadataString
+	MetadataInt32Array
+)
+
+const (
+	metadataPreambleLength = 8
+	metadataEntryLength    = 8
+	maxMetadataString      = 32
+	// MaxMetadataLength bounds the metadata region of the header.
+	MaxMetadataLength int32 = metadataPreambleLength +
+		int32(numMetadataKeys-1)*metadataEntryLength +
+		5*8 + 16 + 2*maxMetadataString
+)
+
+// ReadoutModeReporter is implemented by backends that can describe their
+// readout mode.
+type ReadoutModeReporter interface {
+	ReadoutMode() string
+}
+
+// frameMetadata writes the metadata region of the image header. The layout
+// is fixed by layout; update only rewrites values and does not allocate.
+type frameMetadata struct {
+	buf     *atomic.Buffer
+	offsets [numMetadataKeys]int32
+
+	// Values shared with control goroutines, as float64 bits
+	exposureTime      atomicFloat64
+	frameRate         atomicFloat64
+	sensorTemperature atomicFloat64
+}
+
+// layout writes the entries into buf and returns the metadata length.
+func (m *frameMetadata) layout(buf *atomic.Buffer, serialNumber, readoutMode string) (int32, error) {
+	m.buf = buf
+	pos := int32(metadataPreambleLength)
+	count := uint16(0)
+	var err error
+
+	entry := func(key MetadataKey, typ MetadataType, length int32) {
+		if next := pos + metadataEntryLength + (length+7)&^7; next > buf.Capacity() {
+			err = fmt.Errorf("metadata entry %d exceeds %d bytes", key, buf.Capacity())
+		}
+		if err != nil {
+			return
+		}
+		buf.PutUInt16(pos, uint16(key))
+		buf.PutUInt16(pos+2, uint16(typ))
+		buf.PutInt32(pos+4, length)
+		m.offsets[key] = pos + metadataEntryLength
+		pos += metadataEntryLength + (length+7)&^7
+		count++
+	}
+	str := func(key MetadataKey, s string) {
+		if len(s) > maxMetadataString {
+			s = s[:maxMetadataString]
+		}
+		entry(key, MetadataString, int32(len(s)))
+		if b := []byte(s); err == nil && len(b) > 0 {
+			buf.PutBytesArray(m.offsets[key], &b, 0, int32(len(b)))
+		}
+	}
+
+	entry(MetadataExposureTime, MetadataFloat64, 8)
+	entry(MetadataFrameRate, MetadataFloat64, 8)
+	entry(MetadataSensorTemperature, MetadataFloat64, 8)
+	str(MetadataReadoutMode, readoutMode)
+	str(MetadataSerialNumber, serialNumber)
+	entry(MetadataCropWindow, MetadataInt32Array, 16)
+	entry(MetadataRealtimeNs, MetadataInt64, 8)
+	entry(MetadataMonotonicNs, MetadataInt64, 8)
+
+	if err != nil {
+		return 0, err
+	}
+
+	buf.PutUInt16(0, MetadataVersion)
+	buf.PutUInt16(2, count)
+	buf.PutInt32(4, 0)
+	return pos, nil
+}
+
+// setCropWindow writes the crop window. Acquisition must be stopped.
+func (m *frameMetadata) setCropWindow(g Geometry) {
+	off := m.offsets[MetadataCropWindow]
+	m.buf.PutInt32(off, g.OffsetX)
+	m.buf.PutInt32(off+4, g.OffsetY)
+	m.buf.PutInt32(off+8, g.Width)
+	m.buf.PutInt32(off+12, g.Height)
+}
+
+// update writes the per-frame values for a frame received at the given
+// host times.
+func (m *frameMetadata) update(realtimeNs, monotonicNs int64) {
+	m.putFloat64(MetadataExposureTime, m.exposureTime.Load())
+	m.putFloat64(MetadataFrameRate, m.frameRate.Load())
+	m.putFloat64(MetadataSensorTemperature, m.sensorTemperature.Load())
+	m.buf.PutInt64(m.offsets[MetadataRealtimeNs], realtimeNs)
+	m.buf.PutInt64(m.offsets[MetadataMonotonicNs], monotonicNs)
+}
+
+func (m *frameMetadata) putFloat64(key MetadataKey, v float64) {
+	m.buf.PutInt64(m.offsets[key], int64(math.Float64bits(v)))
+}
@@ -0,0 +1,346 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Telemetry exporters for traces and OpenTelemetry metrics.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	// ExporterOTLP exports over gRPC, configured by the standard
+	// OTEL_EXPORTER_OTLP_* environment variables.
+	ExporterOTLP = "otlp"
+)
+
+// telemetryShutdownTimeout bounds flushing telemetry on shutdown.
+const telemetryShutdownTimeout = shutdownTimeout / 2
+
+type Config struct {
+	Addr string
+	Name string
+	// Exporter is one of ExporterNone, ExporterStdout or ExporterOTLP.
+	Exporter string
+}
+
+// Metrics serves the prometheus metrics of the service and provides the
+// OpenTelemetry tracer and meter providers.
+type Metrics struct {
+	lg       *zap.Logger
+	registry *prometheus.Registry
+	server   *http.Server
+
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	shutdown       []func(context.Context) error
+}
+
+func NewMetrics(lg *zap.Logger, cfg Config) (*Metrics, error) {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collectors.NewGoCollector()); err != nil {
+		return nil, errors.Wrap(err, "register go collector")
+	}
+	if err := registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+		return nil, errors.Wrap(err, "register process collector")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	m := &Metrics{
+		lg:       lg.Named(cfg.Name),
+		registry: registry,
+		server: &http.Server{
+			Addr:    cfg.Addr,
+			Handler: mux,
+		},
+	}
+	if err := m.setupTelemetry(cfg); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Metrics) setupTelemetry(cfg Config) error {
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		m.tracerProvider = trace.NewNoopTracerProvider()
+		m.meterProvider = metric.NewNoopMeterProvider()
+		return nil
+	case ExporterStdout, ExporterOTLP:
+	default:
+		return errors.Errorf("unknown telemetry exporter: %s", cfg.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.Name),
+	))
+	if err != nil {
+		return errors.Wrap(err, "resource")
+	}
+
+	var (
+		spanExporter   sdktrace.SpanExporter
+		metricExporter sdkmetric.Exporter
+	)
+	ctx := context.Background()
+	if cfg.Exporter == ExporterStdout {
+		if spanExporter, err = stdouttrace.New(); err != nil {
+			return errors.Wrap(err, "stdout trace exporter")
+		}
+		if metricExporter, err = stdoutmetric.New(); err != nil {
+			_ = spanExporter.Shutdown(ctx)
+			return errors.Wrap(err, "stdout metric exporter")
+		}
+	} else {
+		if spanExporter, err = otlptracegrpc.New(ctx); err != nil {
+			return errors.Wrap(err, "otlp trace exporter")
+		}
+		if metricExporter, err = otlpmetricgrpc.New(ctx); err != nil {
+			_ = spanExporter.Shutdown(ctx)
+			return errors.Wrap(err, "otlp metric exporter")
+		}
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+		sdkmetric.WithResource(res),
+	)
+	m.tracerProvider = tp
+	m.meterProvider = mp
+	m.shutdown = append(m.shutdown, tp.Shutdown, mp.Shutdown)
+	return nil
+}
+
+func (m *Metrics) TracerProvider() trace.TracerProvider {
+	return m.tracerProvider
+}
+
+func (m *Metrics) MeterProvider() metric.MeterProvider {
+	return m.meterProvider
+}
+
+// Registry returns the registry metrics are exported from.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// RegisterCamera exports the frame pipeline counters of cam and of its
+// outputs. It must be called before acquisition starts.
+func (m *Metrics) RegisterCamera(cam *FLICamera) error {
+	const namespace = "flicamera"
+	labels := prometheus.Labels{"serial_number": cam.SerialNumber()}
+
+	counter := func(name, help string, labels prometheus.Labels, v func() uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: labels,
+		}, func() float64 { return float64(v()) })
+	}
+
+	cs := []prometheus.Collector{
+		counter("frames_received_total", "Frames received from the camera.",
+			labels, cam.stats.framesReceived.Load),
+		counter("grabber_dropped_total", "Frames missing from the camera image counter.",
+			labels, cam.stats.grabberDropped.Load),
+		counter("queue_overruns_total", "Frames dropped because the publisher queue was full.",
+			labels, cam.stats.queueOverruns.Load),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "queue_depth",
+			Help:        "Frames waiting in the publisher queue.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(cam.QueueDepth()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "queue_capacity",
+			Help:        "Frames the publisher queue holds.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(cam.QueueCapacity()) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "frame_rate_hertz",
+			Help:        "Measured rate of frames received from the camera.",
+			ConstLabels: labels,
+		}, cam.stats.frameRate.Load),
+	}
+
+	for _, o := range cam.Outputs() {
+		o := o
+		outputLabels := func(extra ...string) prometheus.Labels {
+			l := prometheus.Labels{
+				"serial_number": cam.SerialNumber(),
+				"output":        o.config.Name,
+			}
+			for i := 0; i+1 < len(extra); i += 2 {
+				l[extra[i]] = extra[i+1]
+			}
+			return l
+		}
+		cs = append(cs,
+			counter("frames_published_total", "Frames accepted by the publication.",
+				outputLabels(), o.stats.framesPublished.Load),
+			counter("frames_abandoned_total", "Frames discarded by the back-pressure policy.",
+				outputLabels(), o.stats.framesAbandoned.Load),
+			counter("publication_dropped_total", "Frames due on the output but not published, other than for a lack of subscribers.",
+				outputLabels(), o.stats.publicationDropped.Load),
+			counter("frames_not_connected_total", "Frames not published because the publication had no subscribers.",
+				outputLabels(), o.stats.notConnected.Load),
+			counter("publications_recreated_total", "Publications recreated after being closed or reaching their max position.",
+				outputLabels(), o.stats.recreated.Load),
+		)
+		for i := 0; i < int(numPublicationStates); i++ {
+			state := PublicationState(i)
+			cs = append(cs, prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace:   namespace,
+				Name:        "publication_state",
+				Help:        "1 for the current state of the output publication, else 0.",
+				ConstLabels: outputLabels("state", state.String()),
+			}, func() float64 {
+				if o.State() == state {
+					return 1
+				}
+				return 0
+			}))
+		}
+		for i := range o.stats.offerResults {
+			r := OfferResult(i)
+			cs = append(cs, counter("offer_results_total", "Publication Offer2 calls by result.",
+				outputLabels("result", r.String()), o.stats.offerResults[r].Load))
+		}
+
+		policy := &o.stats.policy
+		policyLabels := func(p BackPressurePolicy) prometheus.Labels {
+			return outputLabels("policy", p.String())
+		}
+		cs = append(cs,
+			counter("backpressure_dropped_total", "Frames discarded by the back-pressure policy.",
+				policyLabels(PolicyDropNewest), policy.dropNewest.dropped.Load),
+			counter("backpressure_dropped_total", "Frames discarded by the back-pressure policy.",
+				policyLabels(PolicySpin), policy.spin.timeouts.Load),
+			counter("backpressure_retries_total", "Offers retried by the back-pressure policy.",
+				policyLabels(PolicySpin), policy.spin.retries.Load),
+			counter("backpressure_retries_total", "Offers retried by the back-pressure policy.",
+				policyLabels(PolicyBlock), policy.block.retries.Load),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Namespace:   namespace,
+				Name:        "backpressure_blocked_seconds_total",
+				Help:        "Time frames waited to be accepted by the publication.",
+				ConstLabels: policyLabels(PolicyBlock),
+			}, func() float64 { return time.Duration(policy.block.blockedNs.Load()).Seconds() }),
+		)
+
+		latency := prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Name:        "publish_latency_seconds",
+			Help:        "Latency from the frame callback to the frame being published.",
+			ConstLabels: outputLabels(),
+			Buckets:     prometheus.ExponentialBuckets(1e-6, 2, 16),
+		})
+		o.SetLatencyObserver(latency)
+		cs = append(cs, latency)
+	}
+
+	callback := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace:   namespace,
+		Name:        "callback_duration_seconds",
+		Help:        "Time spent in the camera frame callback.",
+		ConstLabels: labels,
+		Buckets:     prometheus.ExponentialBuckets(1e-6, 2, 16),
+	})
+	cs = append(cs, callback)
+
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return errors.Wrap(err, "register")
+		}
+	}
+	cam.SetCallbackObserver(callback)
+	return nil
+}
+
+// RegisterTransport exports the state of the media driver connection.
+func (m *Metrics) RegisterTransport(t *Transport) error {
+	const namespace = "aeron"
+	cs := []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "connected",
+			Help:      "1 while connected to the media driver, else 0.",
+		}, func() float64 {
+			if t.Status().State == TransportConnected {
+				return 1
+			}
+			return 0
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reconnects_total",
+			Help:      "Reconnections to the media driver.",
+		}, func() float64 { return float64(t.Status().Reconnects) }),
+	}
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return errors.Wrap(err, "register")
+		}
+	}
+	return nil
+}
+
+func (m *Metrics) Run(ctx context.Context) error {
+	errc := make(chan error, 1)
+	go func() {
+		defer m.lg.Info("Metrics server stopped")
+		if err := m.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errc <- errors.Wrap(err, "metrics")
+			return
+		}
+		errc <- nil
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		if err := m.server.Shutdown(context.Background()); err != nil {
+			return errors.Wrap(err, "metrics shutdown")
+		}
+		err = <-errc
+	}
+
+	// Flush pending telemetry, giving up before the shutdown watchdog fires
+	// if the collector is unreachable
+	sctx, cancel := context.WithTimeout(context.Background(), telemetryShutdownTimeout)
+	defer cancel()
+	for _, shutdown := range m.shutdown {
+		if serr := shutdown(sctx); serr != nil {
+			m.lg.Warn("Telemetry shutdown", zap.Error(serr))
+		}
+	}
+	return err
+}
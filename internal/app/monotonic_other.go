@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package app
+
+import "time"
+
+// monotonicBase is the origin of monotonicNs on systems without
+// CLOCK_MONOTONIC.
+var monotonicBase = time.Now()
+
+// monotonicNs returns the monotonic time since the process started in ns.
+func monotonicNs() int64 {
+	return int64(time.Since(monotonicBase))
+}
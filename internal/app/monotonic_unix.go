@@ -0,0 +1,12 @@
+//go:build linux || darwin
+
+package app
+
+import "golang.org/x/sys/unix"
+
+// monotonicNs returns CLOCK_MONOTONIC in ns.
+func monotonicNs() int64 {
+	var ts unix.Timespec
+	_ = unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts)
+	return ts.Nano()
+}
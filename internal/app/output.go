@@ -0,0 +1,497 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	aeronatomic "github.com/lirm/aeron-go/aeron/atomic"
+	"go.uber.org/zap"
+)
+
+// OutputConfig configures a publication the frames are fanned out to.
+type OutputConfig struct {
+	// Name identifies the output in logs, metrics and the API.
+	Name     string
+	Channel  string
+	StreamID int32
+	// Decimation publishes the frames whose sequence number is a multiple
+	// of it. Zero and one publish every frame.
+	Decimation int
+	Publish    PublishConfig
+}
+
+// ParseOutputConfig parses an output given as comma separated key=value
+// pairs, e.g. "name=rtc,channel=aeron:ipc,stream=1001,decimation=1,
+// policy=spin,timeout=100us". Missing keys keep their value in defaults.
+func ParseOutputConfig(s string, defaults OutputConfig) (OutputConfig, error) {
+	c := defaults
+	for _, field := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok {
+			return c, fmt.Errorf("output %q: expected key=value: %q", s, field)
+		}
+		var err error
+		switch key {
+		case "name":
+			c.Name = value
+		case "channel":
+			c.Channel = value
+		case "stream":
+			var id int64
+			id, err = strconv.ParseInt(value, 10, 32)
+			c.StreamID = int32(id)
+		case "decimation":
+			c.Decimation, err = strconv.Atoi(value)
+		case "policy":
+			c.Publish.Policy, err = ParseBackPressurePolicy(value)
+		case "timeout":
+			c.Publish.Timeout, err = time.ParseDuration(value)
+		default:
+			err = fmt.Errorf("unknown key")
+		}
+		if err != nil {
+			return c, fmt.Errorf("output %q: %s: %w", s, key, err)
+		}
+	}
+	return c, c.validate()
+}
+
+func (c OutputConfig) validate() error {
+	switch {
+	case c.Name == "":
+		return fmt.Errorf("output: missing name")
+	case c.Channel == "":
+		return fmt.Errorf("output %s: missing channel", c.Name)
+	case c.Decimation < 0:
+		return fmt.Errorf("output %s: invalid decimation: %d", c.Name, c.Decimation)
+	case c.Publish.Policy == PolicySpin && c.Publish.Timeout <= 0:
+		return fmt.Errorf("output %s: invalid publish timeout: %v", c.Name, c.Publish.Timeout)
+	}
+	return nil
+}
+
+// PublicationState is the state of the publication of an output, derived
+// from the results of the offers.
+type PublicationState int32
+
+const (
+	// StateConnected is entered when a frame is accepted.
+	StateConnected PublicationState = iota
+	// StateNotConnected means the publication has no subscribers. Frames
+	// are counted as not connected rather than dropped.
+	StateNotConnected
+	// StateClosed means the publication was closed. It is recreated if the
+	// output has a PublicationFactory, else the output fails.
+	StateClosed
+	// StateMaxPositionExceeded means the publication reached the end of
+	// its log and must be recreated.
+	StateMaxPositionExceeded
+	// StateRecreating means a new publication is being added. Frames are
+	// dropped meanwhile.
+	StateRecreating
+	// StateDisconnected means the Aeron client lost the media driver. Frames
+	// are dropped until the client reconnects and the publication is
+	// recreated.
+	StateDisconnected
+	numPublicationStates
+)
+
+var publicationStateNames = [numPublicationStates]string{
+	"connected",
+	"not-connected",
+	"closed",
+	"max-position-exceeded",
+	"recreating",
+	"disconnected",
+}
+
+func (s PublicationState) String() string {
+	return publicationStateNames[s]
+}
+
+// suspended reports whether frames are dropped without being offered.
+func (s PublicationState) suspended() bool {
+	return s == StateRecreating || s == StateDisconnected
+}
+
+// PublicationFactory adds a publication, e.g. aeron.Aeron.AddPublication.
+type PublicationFactory func(channel string, streamID int32) (Publication, error)
+
+const (
+	recreateMinBackoff = 100 * time.Millisecond
+	recreateMaxBackoff = 5 * time.Second
+)
+
+// publicationRef lets the publication be swapped atomically.
+type publicationRef struct {
+	Publication
+}
+
+// Output offers frames to one publication with its own decimation,
+// back-pressure policy and counters. All outputs are served by the camera
+// publisher goroutine, so an output blocking on back pressure delays the
+// others.
+type Output struct {
+	lg      *zap.Logger
+	config  OutputConfig
+	factory PublicationFactory
+	// publication is replaced by Run when it is recreated.
+	publication atomic.Pointer[publicationRef]
+	// maxMessageLength is the longest frame message the publication
+	// accepts, or 0 if unknown.
+	maxMessageLength atomic.Int32
+	state            atomic.Int32
+	// recreate asks Run to recreate the publication.
+	recreate chan struct{}
+	stats    outputStats
+	latency  Observer
+}
+
+// NewOutput returns an output publishing to publication. A closed
+// publication, or one past its max position, is replaced by one from
+// factory, or fails the output if factory is nil.
+func NewOutput(lg *zap.Logger, config OutputConfig, publication Publication,
+	factory PublicationFactory) (*Output, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	if config.Decimation == 0 {
+		config.Decimation = 1
+	}
+	o := &Output{
+		lg:       lg.With(zap.String("output", config.Name)),
+		config:   config,
+		factory:  factory,
+		recreate: make(chan struct{}, 1),
+		latency:  nopObserver{},
+	}
+	o.publication.Store(&publicationRef{publication})
+	o.maxMessageLength.Store(MaxMessageLength(publication))
+	o.state.Store(int32(StateNotConnected))
+	return o, nil
+}
+
+// Config returns the output configuration.
+func (o *Output) Config() OutputConfig {
+	return o.config
+}
+
+// MaxMessageLength returns the length of the longest frame message the
+// publication accepts, or 0 if unknown.
+func (o *Output) MaxMessageLength() int32 {
+	return o.maxMessageLength.Load()
+}
+
+// State returns the state of the publication.
+func (o *Output) State() PublicationState {
+	return PublicationState(o.state.Load())
+}
+
+// Connected reports whether the publication has subscribers. Publications
+// that cannot tell are connected once they accept a frame.
+func (o *Output) Connected() bool {
+	if c, ok := o.publication.Load().Publication.(interface{ IsConnected() bool }); ok {
+		return c.IsConnected()
+	}
+	return o.State() == StateConnected
+}
+
+// SessionID returns the session id of the publication, if it has one.
+func (o *Output) SessionID() (int32, bool) {
+	if p, ok := o.publication.Load().Publication.(interface{ SessionID() int32 }); ok {
+		return p.SessionID(), true
+	}
+	return 0, false
+}
+
+// Stats returns a snapshot of the output counters.
+func (o *Output) Stats() OutputStats {
+	s := o.stats.snapshot(o.config)
+	s.MaxMessageLength = o.MaxMessageLength()
+	s.State = o.State()
+	return s
+}
+
+// SetLatencyObserver sets the observer of the callback-to-publish latency
+// in seconds. It must be called before acquisition starts.
+func (o *Output) SetLatencyObserver(obs Observer) {
+	o.latency = obs
+}
+
+// Run recreates the publication when the publisher finds it closed or past
+// its max position, or the Aeron client reconnects, until ctx is done. It
+// fails if the output has no PublicationFactory.
+func (o *Output) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-o.recreate:
+		}
+		if o.factory == nil {
+			return fmt.Errorf("output %s: publication %s", o.config.Name, o.State())
+		}
+		if !o.recreatePublication(ctx) {
+			return nil
+		}
+	}
+}
+
+// Disconnect suspends the output after the Aeron client lost the media
+// driver.
+func (o *Output) Disconnect() {
+	o.setState(StateDisconnected)
+}
+
+// Reconnect recreates the publication after the Aeron client reconnected.
+func (o *Output) Reconnect() {
+	o.requestRecreate()
+}
+
+// requestRecreate asks Run to recreate the publication unless it is being
+// recreated already.
+func (o *Output) requestRecreate() {
+	if o.factory != nil {
+		for {
+			old := PublicationState(o.state.Load())
+			if old == StateRecreating {
+				return
+			}
+			if o.state.CompareAndSwap(int32(old), int32(StateRecreating)) {
+				o.logState(old, StateRecreating)
+				break
+			}
+		}
+	}
+	select {
+	case o.recreate <- struct{}{}:
+	default:
+	}
+}
+
+// recreatePublication replaces the publication, retrying with backoff until
+// it succeeds or ctx is done.
+func (o *Output) recreatePublication(ctx context.Context) bool {
+	closePublication(o.publication.Load().Publication)
+	backoff := recreateMinBackoff
+	for {
+		p, err := o.factory(o.config.Channel, o.config.StreamID)
+		if err == nil {
+			// Requests made while recreating are served by this publication
+			select {
+			case <-o.recreate:
+			default:
+			}
+			o.maxMessageLength.Store(MaxMessageLength(p))
+			o.publication.Store(&publicationRef{p})
+			o.stats.recreated.Add(1)
+			o.setState(StateNotConnected)
+			return true
+		}
+		o.lg.Warn("Recreate publication failed", zap.Error(err), zap.Duration("retryIn", backoff))
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > recreateMaxBackoff {
+			backoff = recreateMaxBackoff
+		}
+	}
+}
+
+// Close closes the publication.
+func (o *Output) Close() error {
+	return closePublication(o.publication.Load().Publication)
+}
+
+func closePublication(p Publication) error {
+	if c, ok := p.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// setState enters state s and logs the transition. It reports whether the
+// state changed.
+func (o *Output) setState(s PublicationState) bool {
+	old := PublicationState(o.state.Swap(int32(s)))
+	if old == s {
+		return false
+	}
+	o.logState(old, s)
+	return true
+}
+
+// offerState enters the state s found by an offer, unless the output is
+// suspended. It reports whether the state changed.
+func (o *Output) offerState(s PublicationState) bool {
+	for {
+		old := PublicationState(o.state.Load())
+		if old == s || old.suspended() {
+			return false
+		}
+		if o.state.CompareAndSwap(int32(old), int32(s)) {
+			o.logState(old, s)
+			return true
+		}
+	}
+}
+
+func (o *Output) logState(old, s PublicationState) {
+	fields := []zap.Field{
+		zap.Stringer("state", s),
+		zap.Stringer("previous", old),
+		zap.String("channel", o.config.Channel),
+		zap.Int32("streamId", o.config.StreamID),
+	}
+	switch s {
+	case StateConnected, StateRecreating:
+		o.lg.Info("Publication state", fields...)
+	default:
+		o.lg.Warn("Publication state", fields...)
+	}
+}
+
+// wants reports whether the frame with sequence number seq is published to
+// the output.
+func (o *Output) wants(seq int64) bool {
+	return seq%int64(o.config.Decimation) == 0
+}
+
+// offer publishes header and the frame in slot, retrying as the
+// back-pressure policy allows. PolicyBlock stops retrying while abandon is
+// positive.
+func (o *Output) offer(header *aeronatomic.Buffer, headerLength int32, slot *frameSlot, abandon *atomic.Int32) {
+	if o.State().suspended() {
+		o.stats.publicationDropped.Add(1)
+		return
+	}
+	publication := o.publication.Load().Publication
+	// Retries are timed from the first offer, not from the time the frame
+	// was received, so that a frame that waited in the queue still gets
+	// the retries of its policy
+	start := time.Now()
+	for {
+		ret := publication.Offer2(header, 0, headerLength,
+			slot.buffer, 0, slot.buffer.Capacity(), nil)
+		result := offerResult(ret)
+		o.stats.offerResults[result].Add(1)
+		switch result {
+		case OfferOK:
+			o.stats.framesPublished.Add(1)
+			o.latency.Observe(time.Since(slot.received).Seconds())
+			if o.config.Publish.Policy == PolicyBlock {
+				o.stats.policy.addBlocked(time.Since(start))
+			}
+			o.offerState(StateConnected)
+			return
+		// Retry on AdminAction and BackPressured as the policy allows
+		case OfferAdminAction, OfferBackPressured:
+			if !o.retry(start, result, abandon) {
+				o.stats.framesAbandoned.Add(1)
+				o.stats.publicationDropped.Add(1)
+				return
+			}
+		// Nobody is listening, which is not a drop
+		case OfferNotConnected:
+			o.stats.notConnected.Add(1)
+			o.offerState(StateNotConnected)
+			return
+		// The publication is unusable until recreated
+		case OfferClosed, OfferMaxPositionExceeded:
+			o.stats.publicationDropped.Add(1)
+			state := StateClosed
+			if result == OfferMaxPositionExceeded {
+				state = StateMaxPositionExceeded
+			}
+			if o.offerState(state) {
+				o.requestRecreate()
+			}
+			return
+		}
+	}
+}
+
+// retry reports whether the back-pressure policy retries a frame first
+// offered at start that was not accepted, and counts the decision.
+func (o *Output) retry(start time.Time, result OfferResult, abandon *atomic.Int32) bool {
+	stats := &o.stats.policy
+	switch o.config.Publish.Policy {
+	case PolicyDropNewest:
+		// AdminAction is a term rotation, after which the offer can succeed
+		if result == OfferAdminAction {
+			return true
+		}
+		stats.dropNewest.dropped.Add(1)
+		return false
+	case PolicySpin:
+		if time.Since(start) >= o.config.Publish.Timeout {
+			stats.spin.timeouts.Add(1)
+			return false
+		}
+		stats.spin.retries.Add(1)
+		return true
+	default:
+		// A lost media driver never accepts the frame
+		if abandon.Load() > 0 || o.State().suspended() {
+			return false
+		}
+		stats.block.retries.Add(1)
+		return true
+	}
+}
+
+// outputStats are the counters of an output, updated by the publisher
+// without locking.
+type outputStats struct {
+	framesPublished atomic.Uint64
+	// framesAbandoned counts frames discarded by the back-pressure policy.
+	framesAbandoned atomic.Uint64
+	// publicationDropped counts frames due on the output that were not
+	// published, other than for a lack of subscribers.
+	publicationDropped atomic.Uint64
+	// notConnected counts frames not published for a lack of subscribers.
+	notConnected atomic.Uint64
+	// recreated counts the publications recreated.
+	recreated    atomic.Uint64
+	offerResults [numOfferResults]atomic.Uint64
+	policy       policyStats
+}
+
+// OutputStats is a snapshot of the counters of an output.
+type OutputStats struct {
+	Config OutputConfig
+	// MaxMessageLength is the longest frame message the publication
+	// accepts, or 0 if unknown.
+	MaxMessageLength   int32
+	State              PublicationState
+	FramesPublished    uint64
+	FramesAbandoned    uint64
+	PublicationDropped uint64
+	NotConnected       uint64
+	Recreated          uint64
+	OfferResults       [numOfferResults]uint64
+	Policy             PolicyStats
+}
+
+func (s *outputStats) snapshot(config OutputConfig) OutputStats {
+	o := OutputStats{
+		Config:             config,
+		FramesPublished:    s.framesPublished.Load(),
+		FramesAbandoned:    s.framesAbandoned.Load(),
+		PublicationDropped: s.publicationDropped.Load(),
+		NotConnected:       s.notConnected.Load(),
+		Recreated:          s.recreated.Load(),
+		Policy:             s.policy.snapshot(config.Publish.Policy),
+	}
+	for i := range s.offerResults {
+		o.OfferResults[i] = s.offerResults[i].Load()
+	}
+	return o
+}
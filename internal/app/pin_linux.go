@@ -0,0 +1,12 @@
+package app
+
+import "golang.org/x/sys/unix"
+
+// pinThread pins the calling OS thread to cpus.
+func pinThread(cpus []int) error {
+	var set unix.CPUSet
+	for _, cpu := range cpus {
+		set.Set(cpu)
+	}
+	return unix.SchedSetaffinity(0, &set)
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package app
+
+// pinThread does nothing outside Linux, where the publisher runs on any
+// CPU.
+func pinThread(cpus []int) error {
+	return nil
+}
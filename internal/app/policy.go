@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// BackPressurePolicy selects what happens to a frame the publication does
+// not accept because its subscribers are behind.
+type BackPressurePolicy int
+
+const (
+	// PolicyDropNewest discards the frame, keeping the latency of the
+	// following frames minimal for real-time consumers.
+	PolicyDropNewest BackPressurePolicy = iota
+	// PolicySpin retries until PublishConfig.Timeout has elapsed since the
+	// frame was first offered, then discards it.
+	PolicySpin
+	// PolicyBlock retries until the frame is accepted, for lossless
+	// recordings. The publisher is stalled meanwhile and the queue fills
+	// up, so frames may be dropped as queue overruns instead.
+	PolicyBlock
+	numPolicies
+)
+
+var policyNames = [numPolicies]string{
+	"drop-newest",
+	"spin",
+	"block",
+}
+
+func (p BackPressurePolicy) String() string {
+	return policyNames[p]
+}
+
+func ParseBackPressurePolicy(s string) (BackPressurePolicy, error) {
+	for i, name := range policyNames {
+		if s == name {
+			return BackPressurePolicy(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown back-pressure policy: %s", s)
+}
+
+// PublishConfig configures how frames are offered to the publication.
+type PublishConfig struct {
+	Policy BackPressurePolicy
+	// Timeout bounds the retries of PolicySpin.
+	Timeout time.Duration
+}
+
+// DefaultPublishConfig spins for up to 100µs per frame.
+var DefaultPublishConfig = PublishConfig{
+	Policy:  PolicySpin,
+	Timeout: 100 * time.Microsecond,
+}
+
+// policyStats are the counters kept by each back-pressure policy.
+type policyStats struct {
+	dropNewest struct {
+		dropped atomic.Uint64
+	}
+	spin struct {
+		retries  atomic.Uint64
+		timeouts atomic.Uint64
+	}
+	block struct {
+		retries   atomic.Uint64
+		blockedNs atomic.Uint64
+		maxNs     atomic.Uint64
+	}
+}
+
+// PolicyStats is a snapshot of the back-pressure policy counters. Only the
+// counters of the policy in use change.
+type PolicyStats struct {
+	Policy BackPressurePolicy
+	// DropNewestDropped counts frames discarded by PolicyDropNewest.
+	DropNewestDropped uint64
+	// SpinRetries counts offers retried by PolicySpin and SpinTimeouts the
+	// frames it discarded after the timeout.
+	SpinRetries  uint64
+	SpinTimeouts uint64
+	// BlockRetries counts offers retried by PolicyBlock, Blocked the total
+	// time frames were retried until accepted and MaxBlocked the longest.
+	BlockRetries uint64
+	Blocked      time.Duration
+	MaxBlocked   time.Duration
+}
+
+func (s *policyStats) snapshot(policy BackPressurePolicy) PolicyStats {
+	return PolicyStats{
+		Policy:            policy,
+		DropNewestDropped: s.dropNewest.dropped.Load(),
+		SpinRetries:       s.spin.retries.Load(),
+		SpinTimeouts:      s.spin.timeouts.Load(),
+		BlockRetries:      s.block.retries.Load(),
+		Blocked:           time.Duration(s.block.blockedNs.Load()),
+		MaxBlocked:        time.Duration(s.block.maxNs.Load()),
+	}
+}
+
+// addBlocked records a frame that was retried for d until accepted.
+func (s *policyStats) addBlocked(d time.Duration) {
+	s.block.blockedNs.Add(uint64(d))
+	for {
+		max := s.block.maxNs.Load()
+		if uint64(d) <= max || s.block.maxNs.CompareAndSwap(max, uint64(d)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/atomic"
+	"github.com/lirm/aeron-go/aeron/logbuffer"
+	"github.com/lirm/aeron-go/aeron/logbuffer/term"
+)
+
+// Publication is the part of an aeron.Publication frames are offered to.
+type Publication interface {
+	Offer2(bufferOne *atomic.Buffer, offsetOne int32, lengthOne int32,
+		bufferTwo *atomic.Buffer, offsetTwo int32, lengthTwo int32,
+		reservedValueSupplier term.ReservedValueSupplier) int64
+}
+
+// MaxMessageLength returns the length of the longest message p accepts, or
+// 0 if unknown. Offer2 panics on longer messages. aeron-go does not export
+// the limit of an aeron.Publication, so it is read from its unexported
+// field; other publications may report it with a MaxMessageLength method.
+func MaxMessageLength(p Publication) int32 {
+	if m, ok := p.(interface{ MaxMessageLength() int32 }); ok {
+		return m.MaxMessageLength()
+	}
+	if pub, ok := p.(*aeron.Publication); ok && pub != nil {
+		f := reflect.ValueOf(pub).Elem().FieldByName("maxMessageLength")
+		if f.IsValid() && f.Kind() == reflect.Int32 {
+			return int32(f.Int())
+		}
+	}
+	return 0
+}
+
+// FrameMessageLength returns the length of the message carrying an image
+// of imageSize bytes.
+func FrameMessageLength(imageSize int32) int32 {
+	return MaxImageHeaderLength + imageSize
+}
+
+// termLengthFor returns the shortest valid term length whose max message
+// length, an eighth of the term, fits messageLength.
+func termLengthFor(messageLength int32) (int64, error) {
+	const maxTermLength = 1 << 30
+	termLength := int64(logbuffer.TermMinLength)
+	for logbuffer.ComputeMaxMessageLength(int32(termLength)) < messageLength {
+		termLength <<= 1
+		if termLength > maxTermLength {
+			return 0, fmt.Errorf("%d byte messages exceed the max message length of the longest term", messageLength)
+		}
+	}
+	return termLength, nil
+}
+
+// ChannelWithTermLength returns channel with the term-length parameter set
+// to the shortest term whose max message length fits messageLength.
+func ChannelWithTermLength(channel string, messageLength int32) (string, error) {
+	termLength, err := termLengthFor(messageLength)
+	if err != nil {
+		return "", err
+	}
+	uri, err := aeron.ParseChannelUri(channel)
+	if err != nil {
+		return "", fmt.Errorf("channel %q: %w", channel, err)
+	}
+	uri.Set(aeron.TermLengthParamName, strconv.FormatInt(termLength, 10))
+	return uri.String(), nil
+}
+
+// checkMessageLength returns an error suggesting a channel with a longer
+// term if messages of messageLength bytes do not fit the publication of o.
+func (o *Output) checkMessageLength(messageLength int32) error {
+	max := o.MaxMessageLength()
+	if max <= 0 || messageLength <= max {
+		return nil
+	}
+	suggestion := ""
+	if channel, err := ChannelWithTermLength(o.config.Channel, messageLength); err == nil {
+		suggestion = fmt.Sprintf(", use a longer term such as %s", channel)
+	}
+	return fmt.Errorf("%w: %d byte frames exceed the %d byte max message length of output %s%s",
+		ErrOutOfRange, messageLength, max, o.config.Name, suggestion)
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"sync/atomic"
+	"time"
+
+	aeronatomic "github.com/lirm/aeron-go/aeron/atomic"
+)
+
+// frameSlot is a frame copied out of the camera callback.
+type frameSlot struct {
+	data   []byte
+	buffer *aeronatomic.Buffer
+
+	received    time.Time
+	monotonicNs int64
+	sequence    int64
+}
+
+// frameQueue is a bounded single-producer single-consumer ring of frame
+// slots. The camera callback claims and commits slots, the publisher peeks
+// and releases them; neither side locks or allocates.
+type frameQueue struct {
+	slots []frameSlot
+	mask  uint64
+
+	// head is the next slot to release, written by the consumer only.
+	head atomic.Uint64
+	_    [56]byte
+	// tail is the next slot to claim, written by the producer only.
+	tail atomic.Uint64
+	_    [56]byte
+}
+
+// newFrameQueue returns a queue of at least length slots, rounded up to a
+// power of two.
+func newFrameQueue(length int) *frameQueue {
+	n := 1
+	for n < length {
+		n <<= 1
+	}
+	return &frameQueue{
+		slots: make([]frameSlot, n),
+		mask:  uint64(n - 1),
+	}
+}
+
+// resize allocates the slots for frames of size bytes. The queue must be
+// empty and neither side active.
+func (q *frameQueue) resize(size int) {
+	for i := range q.slots {
+		s := &q.slots[i]
+		if len(s.data) == size {
+			continue
+		}
+		s.data = make([]byte, size)
+		s.buffer = aeronatomic.MakeBuffer(s.data)
+	}
+}
+
+// claim returns the slot to fill with the next frame, or nil if the queue is
+// full.
+func (q *frameQueue) claim() *frameSlot {
+	tail := q.tail.Load()
+	if tail-q.head.Load() > q.mask {
+		return nil
+	}
+	return &q.slots[tail&q.mask]
+}
+
+// commit hands the claimed slot to the consumer.
+func (q *frameQueue) commit() {
+	q.tail.Add(1)
+}
+
+// peek returns the oldest committed slot, or nil if the queue is empty.
+func (q *frameQueue) peek() *frameSlot {
+	head := q.head.Load()
+	if head == q.tail.Load() {
+		return nil
+	}
+	return &q.slots[head&q.mask]
+}
+
+// release returns the peeked slot to the producer.
+func (q *frameQueue) release() {
+	q.head.Add(1)
+}
+
+// len returns the number of committed slots.
+func (q *frameQueue) len() int {
+	return int(q.tail.Load() - q.head.Load())
+}
+
+func (q *frameQueue) capacity() int {
+	return len(q.slots)
+}
+
+// clear discards the committed slots. The consumer must not be active.
+func (q *frameQueue) clear() {
+	q.head.Store(q.tail.Load())
+}
@@ -0,0 +1,218 @@
+package app
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	syncatomic "sync/atomic"
+	"time"
+)
+
+// ErrNotAcquiring is returned for snapshots while acquisition is stopped or
+// paused.
+var ErrNotAcquiring = errors.New("camera not acquiring")
+
+// MaxSnapshotFrames bounds the number of frames a snapshot averages.
+const MaxSnapshotFrames = 1000
+
+// snapshotTimeout is the time a snapshot waits for frames on top of their
+// frame periods.
+const snapshotTimeout = 2 * time.Second
+
+// Snapshot is a published frame, or the mean of consecutive published
+// frames, with the camera configuration they were taken with.
+type Snapshot struct {
+	Geometry     Geometry
+	SerialNumber string
+	Model        string
+	ReadoutMode  string
+	// Exposure settings and sensor temperature, NaN when unknown
+	ExposureTime      float64
+	FrameRate         float64
+	SensorTemperature float64
+
+	// Frames is the number of frames averaged, from FirstSequence to
+	// SequenceNumber received between Start and End.
+	Frames         int
+	FirstSequence  int64
+	SequenceNumber int64
+	Start          time.Time
+	End            time.Time
+
+	// Pixels is the image in row-major order, the mean rounded to the
+	// nearest integer. Mean is the unrounded mean if Frames > 1.
+	Pixels []uint16
+	Mean   []float32
+}
+
+// snapshotRequest accumulates the frames of a snapshot.
+type snapshotRequest struct {
+	frames int
+	sum    []uint32
+	snap   Snapshot
+	done   chan struct{}
+}
+
+// reset restarts the accumulation for frames of geometry g.
+func (r *snapshotRequest) reset(g Geometry) {
+	n := int(g.Width) * int(g.Height)
+	if len(r.sum) != n {
+		r.sum = make([]uint32, n)
+	} else {
+		for i := range r.sum {
+			r.sum[i] = 0
+		}
+	}
+	r.snap.Geometry = g
+	r.snap.Frames = 0
+}
+
+// add accumulates the little-endian pixels of a frame.
+func (r *snapshotRequest) add(slot *frameSlot) {
+	for i := range r.sum {
+		r.sum[i] += uint32(binary.LittleEndian.Uint16(slot.data[2*i:]))
+	}
+	if r.snap.Frames == 0 {
+		r.snap.FirstSequence = slot.sequence
+		r.snap.Start = slot.received
+	}
+	r.snap.Frames++
+	r.snap.SequenceNumber = slot.sequence
+	r.snap.End = slot.received
+}
+
+// finish computes the images from the sums.
+func (r *snapshotRequest) finish() {
+	n := uint32(r.snap.Frames)
+	r.snap.Pixels = make([]uint16, len(r.sum))
+	for i, s := range r.sum {
+		r.snap.Pixels[i] = uint16((s + n/2) / n)
+	}
+	if n > 1 {
+		r.snap.Mean = make([]float32, len(r.sum))
+		for i, s := range r.sum {
+			r.snap.Mean[i] = float32(float64(s) / float64(n))
+		}
+	}
+	r.sum = nil
+}
+
+// snapshotRequests are the snapshots waiting for frames. pending lets the
+// publisher skip the lock while there are none.
+type snapshotRequests struct {
+	pending  syncatomic.Bool
+	mu       sync.Mutex
+	requests []*snapshotRequest
+}
+
+func (s *snapshotRequests) add(r *snapshotRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r)
+	s.pending.Store(true)
+}
+
+// remove removes r and reports whether it was still waiting.
+func (s *snapshotRequests) remove(r *snapshotRequest) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, req := range s.requests {
+		if req == r {
+			s.requests = append(s.requests[:i], s.requests[i+1:]...)
+			s.pending.Store(len(s.requests) > 0)
+			return true
+		}
+	}
+	return false
+}
+
+// captureSnapshots adds a published frame to the waiting snapshots and
+// completes those that have all their frames. It runs on the publisher, so
+// the geometry is read from the frame header rather than under f.mu.
+func (f *FLICamera) captureSnapshots(slot *frameSlot) {
+	s := &f.snapshots
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g := Geometry{
+		Width:            f.frame.SizeX(),
+		Height:           f.frame.SizeY(),
+		OffsetX:          f.frame.OffsetX(),
+		OffsetY:          f.frame.OffsetY(),
+		ImageSizeInBytes: int32(len(slot.data)),
+	}
+	waiting := s.requests[:0]
+	for _, r := range s.requests {
+		// Frames of different geometries are not averaged
+		if r.snap.Geometry != g {
+			r.reset(g)
+		}
+		r.add(slot)
+		if r.snap.Frames < r.frames {
+			waiting = append(waiting, r)
+			continue
+		}
+		r.snap.ExposureTime = f.metadata.exposureTime.Load()
+		r.snap.FrameRate = f.metadata.frameRate.Load()
+		r.snap.SensorTemperature = f.metadata.sensorTemperature.Load()
+		r.finish()
+		close(r.done)
+	}
+	for i := len(waiting); i < len(s.requests); i++ {
+		s.requests[i] = nil
+	}
+	s.requests = waiting
+	s.pending.Store(len(waiting) > 0)
+}
+
+// Snapshot waits for the next frames published and returns them averaged.
+// It fails with ErrNotAcquiring while acquisition is stopped or paused, and
+// with context.DeadlineExceeded if the frames do not arrive within their
+// frame periods and a margin.
+func (f *FLICamera) Snapshot(ctx context.Context, frames int) (*Snapshot, error) {
+	if frames < 1 || frames > MaxSnapshotFrames {
+		return nil, fmt.Errorf("%w: %d frames not in [1, %d]", ErrOutOfRange, frames, MaxSnapshotFrames)
+	}
+
+	f.mu.Lock()
+	running := f.acquiring && !f.paused
+	g := f.geometry
+	f.mu.Unlock()
+	if !running {
+		return nil, ErrNotAcquiring
+	}
+
+	timeout := snapshotTimeout
+	if fps := f.metadata.frameRate.Load(); fps > 0 {
+		timeout += time.Duration(float64(frames) / fps * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	r := &snapshotRequest{frames: frames, done: make(chan struct{})}
+	r.reset(g)
+	f.snapshots.add(r)
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		if f.snapshots.remove(r) {
+			return nil, fmt.Errorf("snapshot: %d of %d frames: %w", r.snap.Frames, frames, ctx.Err())
+		}
+		// Completed while timing out
+		<-r.done
+	}
+
+	snap := &r.snap
+	snap.SerialNumber = f.serialNumber
+	snap.Model = f.model
+	f.mu.Lock()
+	if rm, ok := f.backend.(ReadoutModeReporter); ok {
+		snap.ReadoutMode = rm.ReadoutMode()
+	}
+	f.mu.Unlock()
+	return snap, nil
+}
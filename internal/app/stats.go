@@ -0,0 +1,135 @@
+package app
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/lirm/aeron-go/aeron"
+)
+
+// OfferResult is the outcome class of a publication Offer2 call.
+type OfferResult int
+
+const (
+	OfferOK OfferResult = iota
+	OfferNotConnected
+	OfferBackPressured
+	OfferAdminAction
+	OfferClosed
+	OfferMaxPositionExceeded
+	numOfferResults
+)
+
+var offerResultNames = [numOfferResults]string{
+	"ok",
+	"not_connected",
+	"back_pressured",
+	"admin_action",
+	"closed",
+	"max_position_exceeded",
+}
+
+func (r OfferResult) String() string {
+	return offerResultNames[r]
+}
+
+func offerResult(ret int64) OfferResult {
+	switch ret {
+	case aeron.NotConnected:
+		return OfferNotConnected
+	case aeron.BackPressured:
+		return OfferBackPressured
+	case aeron.AdminAction:
+		return OfferAdminAction
+	case aeron.PublicationClosed:
+		return OfferClosed
+	case aeron.MaxPositionExceeded:
+		return OfferMaxPositionExceeded
+	default:
+		return OfferOK
+	}
+}
+
+// Observer records a sample, e.g. a prometheus histogram.
+type Observer interface {
+	Observe(float64)
+}
+
+type nopObserver struct{}
+
+func (nopObserver) Observe(float64) {}
+
+// pipelineStats are the frame acquisition counters, updated from the frame
+// callback without locking. The publishing counters are kept per output.
+type pipelineStats struct {
+	framesReceived atomic.Uint64
+	// grabberDropped counts gaps in the backend image counter.
+	grabberDropped atomic.Uint64
+	// queueOverruns counts frames dropped because the publisher queue was
+	// full, before reaching any output.
+	queueOverruns  atomic.Uint64
+	sequenceNumber atomic.Int64
+	frameRate      atomicFloat64
+}
+
+// atomicFloat64 is a float64 stored as its bits for atomic access.
+type atomicFloat64 struct {
+	bits atomic.Uint64
+}
+
+func (f *atomicFloat64) Load() float64 {
+	return math.Float64frombits(f.bits.Load())
+}
+
+func (f *atomicFloat64) Store(v float64) {
+	f.bits.Store(math.Float64bits(v))
+}
+
+// PipelineStats is a snapshot of the frame pipeline counters.
+type PipelineStats struct {
+	FramesReceived uint64
+	GrabberDropped uint64
+	QueueOverruns  uint64
+	// SequenceNumber is the sequence number of the latest frame.
+	SequenceNumber int64
+	// FrameRate is the measured rate of received frames in Hz.
+	FrameRate float64
+	Outputs   []OutputStats
+}
+
+func (s *pipelineStats) snapshot(outputs []*Output) PipelineStats {
+	p := PipelineStats{
+		FramesReceived: s.framesReceived.Load(),
+		GrabberDropped: s.grabberDropped.Load(),
+		QueueOverruns:  s.queueOverruns.Load(),
+		SequenceNumber: s.sequenceNumber.Load(),
+		FrameRate:      s.frameRate.Load(),
+		Outputs:        make([]OutputStats, len(outputs)),
+	}
+	for i, o := range outputs {
+		p.Outputs[i] = o.Stats()
+	}
+	return p
+}
+
+// measureFrameRate updates the measured frame rate from the received frame
+// count at every interval until done is closed.
+func (s *pipelineStats) measureFrameRate(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := s.framesReceived.Load()
+	lastTime := time.Now()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			n := s.framesReceived.Load()
+			fps := float64(n-last) / now.Sub(lastTime).Seconds()
+			s.frameRate.Store(fps)
+			last, lastTime = n, now
+		}
+	}
+}
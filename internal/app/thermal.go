@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultThermalInterval is the interval between temperature readings when
+// they are not logged.
+const DefaultThermalInterval = 10 * time.Second
+
+type ThermalConfig struct {
+	// Interval between temperature readings, each of which is logged. Zero
+	// or less disables logging; readings are then taken every
+	// DefaultThermalInterval to keep track of the sensor stability.
+	Interval time.Duration
+	// Tolerance is the maximum deviation of the sensor from the setpoint
+	// in degrees Celsius for it to count as stable.
+	Tolerance float64
+	// Window is how long the sensor must stay within tolerance before it
+	// is reported as stable.
+	Window time.Duration
+}
+
+// ThermalStatus is a temperature reading with the sensor stability state.
+type ThermalStatus struct {
+	Temperatures
+	Setpoint float64
+	Stable   bool
+	Time     time.Time
+}
+
+// ThermalMonitor periodically reads and logs the camera temperatures and
+// tracks whether the sensor has settled at its setpoint.
+type ThermalMonitor struct {
+	cam    *FLICamera
+	lg     *zap.Logger
+	config ThermalConfig
+
+	mu          sync.Mutex
+	stableSince time.Time
+}
+
+func NewThermalMonitor(cam *FLICamera, lg *zap.Logger, config ThermalConfig) *ThermalMonitor {
+	return &ThermalMonitor{
+		cam:    cam,
+		lg:     lg,
+		config: config,
+	}
+}
+
+// Sample reads the current temperatures and updates the stability state.
+func (m *ThermalMonitor) Sample() (ThermalStatus, error) {
+	temps, err := m.cam.Temperatures()
+	if err != nil {
+		return ThermalStatus{}, err
+	}
+	setpoint, err := m.cam.SensorSetpoint()
+	if err != nil {
+		return ThermalStatus{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if math.Abs(temps.Sensor-setpoint.Value) > m.config.Tolerance {
+		m.stableSince = time.Time{}
+	} else if m.stableSince.IsZero() {
+		m.stableSince = now
+	}
+
+	return ThermalStatus{
+		Temperatures: temps,
+		Setpoint:     setpoint.Value,
+		Stable:       !m.stableSince.IsZero() && now.Sub(m.stableSince) >= m.config.Window,
+		Time:         now,
+	}, nil
+}
+
+func (m *ThermalMonitor) Run(ctx context.Context) error {
+	interval, logging := m.config.Interval, m.config.Interval > 0
+	if !logging {
+		m.lg.Info("Temperature logging disabled")
+		interval = DefaultThermalInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := m.Sample()
+		switch {
+		case errors.Is(err, ErrNotSupported):
+			m.lg.Info("Temperature monitoring not supported by camera backend")
+			return nil
+		case err != nil:
+			m.lg.Warn("Reading temperatures", zap.Error(err))
+		case logging:
+			m.lg.Info("Temperature",
+				zap.Float64("sensor", status.Sensor),
+				zap.Float64("setpoint", status.Setpoint),
+				zap.Float64("motherboard", status.Motherboard),
+				zap.Float64("peltier", status.Peltier),
+				zap.Float64("heatsink", status.Heatsink),
+				zap.Float64("tecPower", status.TECPower),
+				zap.Bool("stable", status.Stable),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
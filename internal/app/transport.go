@@ -0,0 +1,374 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/counters"
+	rb "github.com/lirm/aeron-go/aeron/ringbuffer"
+	"github.com/lirm/aeron-go/aeron/util/memmap"
+	"go.uber.org/zap"
+)
+
+// TransportConfig configures the connection to the Aeron media driver.
+type TransportConfig struct {
+	// Dir is the media driver directory, empty for the aeron-go default.
+	Dir string
+	// DriverTimeout is the time without a driver heartbeat after which the
+	// connection is lost.
+	DriverTimeout time.Duration
+}
+
+// DefaultDriverTimeout is the media driver timeout of aeron-go.
+const DefaultDriverTimeout = 10 * time.Second
+
+// TransportState is the state of the connection to the media driver.
+type TransportState int
+
+const (
+	TransportConnected TransportState = iota
+	// TransportReconnecting means the connection was lost and the
+	// transport is reconnecting with backoff.
+	TransportReconnecting
+	numTransportStates
+)
+
+var transportStateNames = [numTransportStates]string{
+	"connected",
+	"reconnecting",
+}
+
+func (s TransportState) String() string {
+	return transportStateNames[s]
+}
+
+// TransportStatus is a snapshot of the connection to the media driver.
+type TransportStatus struct {
+	State TransportState
+	// Since is the time the state was entered.
+	Since time.Time
+	// LastError is the error the connection was last lost with.
+	LastError  error
+	Reconnects uint64
+}
+
+const (
+	transportCheckInterval = 500 * time.Millisecond
+	reconnectMinBackoff    = 100 * time.Millisecond
+	reconnectMaxBackoff    = 10 * time.Second
+	// clientCloseTimeout bounds closing a client whose driver is gone.
+	clientCloseTimeout = 10 * time.Second
+)
+
+// Transport keeps an Aeron client connected to the media driver. It
+// detects a lost driver from the client error handler, the client closing
+// or the driver heartbeat going stale, then reconnects with backoff and
+// runs the OnConnect hooks to rebuild the resources of the service.
+type Transport struct {
+	lg     *zap.Logger
+	config TransportConfig
+
+	mu     sync.Mutex
+	client *aeron.Aeron
+	// generation counts the clients, so that errors reported by a client
+	// being closed are ignored.
+	generation   uint64
+	cnc          *memmap.File
+	toDriver     rb.ManyToOne
+	status       TransportStatus
+	onConnect    []func(*aeron.Aeron) error
+	onDisconnect []func()
+
+	lost chan error
+}
+
+// NewTransport connects to the media driver. It fails if the driver is not
+// running; later losses of the driver are recovered by Run.
+func NewTransport(lg *zap.Logger, config TransportConfig) (*Transport, error) {
+	if config.DriverTimeout <= 0 {
+		config.DriverTimeout = DefaultDriverTimeout
+	}
+	t := &Transport{
+		lg:     lg.Named("aeron"),
+		config: config,
+		lost:   make(chan error, 1),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.connect(); err != nil {
+		return nil, err
+	}
+	t.status = TransportStatus{
+		State: TransportConnected,
+		Since: time.Now(),
+	}
+	return t, nil
+}
+
+// connect connects a new client. t.mu must be held.
+func (t *Transport) connect() error {
+	t.generation++
+	generation := t.generation
+
+	ctx := aeron.NewContext().
+		MediaDriverTimeout(t.config.DriverTimeout).
+		ErrorHandler(func(err error) {
+			t.clientError(generation, err)
+		})
+	if t.config.Dir != "" {
+		ctx.AeronDir(t.config.Dir)
+	}
+
+	// The heartbeat of the driver is read from its own mapping of the CnC
+	// file, which still refers to the old driver after a restart
+	meta, cnc, err := counters.MapFile(ctx.CncFileName())
+	if err != nil {
+		return fmt.Errorf("aeron: map CnC file: %w", err)
+	}
+	t.toDriver.Init(meta.ToDriverBuf.Get())
+	// The CnC file of a dead driver stays behind until it restarts
+	if age := t.heartbeatAge(); age >= t.config.DriverTimeout {
+		cnc.Close()
+		return fmt.Errorf("aeron: media driver inactive for %v", age.Round(time.Millisecond))
+	}
+	client, err := aeron.Connect(ctx)
+	if err != nil {
+		cnc.Close()
+		return fmt.Errorf("aeron connect: %w", err)
+	}
+	t.client = client
+	t.cnc = cnc
+	return nil
+}
+
+// clientError handles an error reported by a client. aeron-go stops the
+// client conductor after the driver or client timeouts it reports, so any
+// error is checked against the driver heartbeat.
+func (t *Transport) clientError(generation uint64, err error) {
+	t.mu.Lock()
+	current := generation == t.generation
+	t.mu.Unlock()
+	if !current {
+		return
+	}
+	t.lg.Warn("Aeron client error", zap.Error(err))
+	if t.driverAlive() {
+		return
+	}
+	select {
+	case t.lost <- err:
+	default:
+	}
+}
+
+// driverAlive reports whether the client is open and the driver heartbeat
+// is recent.
+func (t *Transport) driverAlive() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client == nil || t.client.IsClosed() {
+		return false
+	}
+	return t.heartbeatAge() < t.config.DriverTimeout
+}
+
+// heartbeatAge returns the time since the driver last consumed commands.
+func (t *Transport) heartbeatAge() time.Duration {
+	return time.Since(time.UnixMilli(t.toDriver.ConsumerHeartbeatTime()))
+}
+
+// Client returns the current client, or nil while reconnecting.
+func (t *Transport) Client() *aeron.Aeron {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.status.State != TransportConnected {
+		return nil
+	}
+	return t.client
+}
+
+// AddPublication adds a publication with the current client. It is a
+// PublicationFactory.
+func (t *Transport) AddPublication(channel string, streamID int32) (Publication, error) {
+	client := t.Client()
+	if client == nil {
+		return nil, errors.New("aeron: reconnecting to the media driver")
+	}
+	publication, err := client.AddPublication(channel, streamID)
+	if err != nil {
+		return nil, err
+	}
+	return publication, nil
+}
+
+// AddSubscription adds a subscription with the current client. It is a
+// SubscriptionFactory.
+func (t *Transport) AddSubscription(channel string, streamID int32) (Subscription, error) {
+	client := t.Client()
+	if client == nil {
+		return nil, errors.New("aeron: reconnecting to the media driver")
+	}
+	subscription, err := client.AddSubscription(channel, streamID)
+	if err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// OnConnect adds a hook run with the new client after every reconnection.
+// A failing hook makes the transport reconnect again. It must be called
+// before Run.
+func (t *Transport) OnConnect(f func(*aeron.Aeron) error) {
+	t.onConnect = append(t.onConnect, f)
+}
+
+// OnDisconnect adds a hook run when the connection is lost. It must be
+// called before Run.
+func (t *Transport) OnDisconnect(f func()) {
+	t.onDisconnect = append(t.onDisconnect, f)
+}
+
+// Status returns the state of the connection.
+func (t *Transport) Status() TransportStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.status
+}
+
+// Run watches the connection and reconnects when it is lost, until ctx is
+// done.
+func (t *Transport) Run(ctx context.Context) error {
+	ticker := time.NewTicker(transportCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return nil
+		case err = <-t.lost:
+		case <-ticker.C:
+			if t.driverAlive() {
+				continue
+			}
+			err = errors.New("media driver heartbeat timed out")
+		}
+		if !t.reconnect(ctx, err) {
+			return nil
+		}
+	}
+}
+
+// reconnect replaces the client after the connection was lost with cause,
+// retrying with backoff until the client connects and the hooks succeed or
+// ctx is done.
+func (t *Transport) reconnect(ctx context.Context, cause error) bool {
+	t.lg.Error("Lost the media driver, reconnecting", zap.Error(cause))
+	t.mu.Lock()
+	t.status = TransportStatus{
+		State:      TransportReconnecting,
+		Since:      time.Now(),
+		LastError:  cause,
+		Reconnects: t.status.Reconnects,
+	}
+	t.mu.Unlock()
+	for _, f := range t.onDisconnect {
+		f()
+	}
+
+	backoff := reconnectMinBackoff
+	for {
+		t.mu.Lock()
+		t.closeClient()
+		err := t.connect()
+		t.mu.Unlock()
+
+		if err == nil {
+			t.mu.Lock()
+			client := t.client
+			t.status.State = TransportConnected
+			t.mu.Unlock()
+			for _, f := range t.onConnect {
+				if err = f(client); err != nil {
+					break
+				}
+			}
+			if err == nil {
+				t.mu.Lock()
+				t.status.Since = time.Now()
+				t.status.Reconnects++
+				t.mu.Unlock()
+				// Losses reported before reconnecting are handled
+				select {
+				case <-t.lost:
+				default:
+				}
+				t.lg.Info("Reconnected to the media driver")
+				return true
+			}
+			t.mu.Lock()
+			t.status.State = TransportReconnecting
+			t.mu.Unlock()
+		}
+		t.lg.Warn("Reconnect failed", zap.Error(err), zap.Duration("retryIn", backoff))
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > reconnectMaxBackoff {
+			backoff = reconnectMaxBackoff
+		}
+	}
+}
+
+// closeClient closes the current client in the background, as closing
+// waits for a conductor that may be stuck on the lost driver. t.mu must be
+// held.
+func (t *Transport) closeClient() {
+	client, cnc := t.client, t.cnc
+	t.client, t.cnc = nil, nil
+	// Errors reported while closing belong to the old generation
+	t.generation++
+	if client == nil {
+		return
+	}
+	go func() {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			client.Close()
+		}()
+		select {
+		case <-done:
+		case <-time.After(clientCloseTimeout):
+			t.lg.Warn("Closing the Aeron client timed out")
+		}
+		cnc.Close()
+	}()
+}
+
+// Close closes the client.
+func (t *Transport) Close() error {
+	t.mu.Lock()
+	client, cnc := t.client, t.cnc
+	t.client, t.cnc = nil, nil
+	t.generation++
+	t.mu.Unlock()
+
+	if client == nil {
+		return nil
+	}
+	err := client.Close()
+	cnc.Close()
+	return err
+}
@@ -0,0 +1,376 @@
+// Package fits implements the subset of the FITS format needed to read
+// 16-bit image cubes and to write single images.
+package fits
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	BlockSize = 2880
+	cardSize  = 80
+)
+
+// Card is a single header keyword record.
+type Card struct {
+	Key     string
+	Value   string
+	Comment string
+}
+
+// Header is an ordered list of header cards.
+type Header struct {
+	Cards []Card
+}
+
+func (h *Header) lookup(key string) (string, bool) {
+	for _, c := range h.Cards {
+		if c.Key == key {
+			return c.Value, true
+		}
+	}
+	return "", false
+}
+
+// Int returns the integer value of the keyword.
+func (h *Header) Int(key string) (int, bool) {
+	v, ok := h.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+// Float returns the floating point value of the keyword.
+func (h *Header) Float(key string) (float64, bool) {
+	v, ok := h.lookup(key)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(strings.Replace(v, "D", "E", 1), 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// String returns the string value of the keyword without quotes.
+func (h *Header) String(key string) (string, bool) {
+	v, ok := h.lookup(key)
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(strings.ReplaceAll(strings.Trim(v, "'"), "''", "'")), true
+}
+
+// Set sets the keyword to value, replacing an existing card. Values are
+// bool, integer, float64 or string; strings are quoted.
+func (h *Header) Set(key string, value interface{}, comment string) {
+	card := Card{Key: key, Value: formatValue(value), Comment: comment}
+	for i := range h.Cards {
+		if h.Cards[i].Key == key {
+			h.Cards[i] = card
+			return
+		}
+	}
+	h.Cards = append(h.Cards, card)
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "T"
+		}
+		return "F"
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		s := strconv.FormatFloat(v, 'G', -1, 64)
+		if !strings.ContainsAny(s, ".E") {
+			s += ".0"
+		}
+		return s
+	case string:
+		s := "'" + strings.ReplaceAll(v, "'", "''")
+		// Strings are at least 8 characters between the quotes
+		for len(s) < 9 {
+			s += " "
+		}
+		return s + "'"
+	}
+	panic(fmt.Sprintf("fits: unsupported value type %T", value))
+}
+
+// formatCard returns the 80 character record of c.
+func formatCard(c Card) (string, error) {
+	if len(c.Key) > 8 {
+		return "", fmt.Errorf("fits: keyword %s longer than 8 characters", c.Key)
+	}
+	s := fmt.Sprintf("%-8s", c.Key)
+	if c.Value != "" {
+		if strings.HasPrefix(c.Value, "'") {
+			s += "= " + c.Value
+		} else {
+			// Fixed format, right justified in column 30
+			s += fmt.Sprintf("= %20s", c.Value)
+		}
+		if c.Comment != "" {
+			s += " / " + c.Comment
+		}
+	} else if c.Comment != "" {
+		s += "  " + c.Comment
+	}
+	if len(s) > cardSize {
+		if len(s)-len(c.Comment) > cardSize {
+			return "", fmt.Errorf("fits: keyword %s value too long", c.Key)
+		}
+		s = s[:cardSize]
+	}
+	return fmt.Sprintf("%-80s", s), nil
+}
+
+// writeHeader writes the cards and the END card, padded to a block.
+func writeHeader(w io.Writer, cards []Card) error {
+	var b strings.Builder
+	for _, c := range cards {
+		s, err := formatCard(c)
+		if err != nil {
+			return err
+		}
+		b.WriteString(s)
+	}
+	b.WriteString(fmt.Sprintf("%-80s", "END"))
+	if n := b.Len() % BlockSize; n != 0 {
+		b.WriteString(strings.Repeat(" ", BlockSize-n))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// imageCards returns the mandatory cards of a primary image HDU followed by
+// the cards of h other than those.
+func imageCards(h *Header, bitpix, width, height int) []Card {
+	var m Header
+	m.Set("SIMPLE", true, "conforms to the FITS standard")
+	m.Set("BITPIX", bitpix, "")
+	m.Set("NAXIS", 2, "")
+	m.Set("NAXIS1", width, "")
+	m.Set("NAXIS2", height, "")
+	if bitpix == 16 {
+		m.Set("BZERO", 32768.0, "unsigned 16-bit pixels")
+		m.Set("BSCALE", 1.0, "")
+	}
+	if h == nil {
+		return m.Cards
+	}
+	for _, c := range h.Cards {
+		if _, ok := m.lookup(c.Key); !ok && c.Key != "END" {
+			m.Cards = append(m.Cards, c)
+		}
+	}
+	return m.Cards
+}
+
+// writeData pads the data written to a block.
+func writeData(w io.Writer, data []byte) error {
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if n := len(data) % BlockSize; n != 0 {
+		_, err := w.Write(make([]byte, BlockSize-n))
+		return err
+	}
+	return nil
+}
+
+// WriteUint16 writes a FITS file whose primary HDU is the width × height
+// image pix of unsigned 16-bit pixels, with the cards of h after the
+// mandatory ones.
+func WriteUint16(w io.Writer, h *Header, width, height int, pix []uint16) error {
+	if len(pix) != width*height {
+		return fmt.Errorf("fits: %d pixels for a %dx%d image", len(pix), width, height)
+	}
+	if err := writeHeader(w, imageCards(h, 16, width, height)); err != nil {
+		return err
+	}
+	data := make([]byte, 2*len(pix))
+	for i, v := range pix {
+		binary.BigEndian.PutUint16(data[2*i:], v^0x8000)
+	}
+	return writeData(w, data)
+}
+
+// WriteFloat32 writes a FITS file whose primary HDU is the width × height
+// image pix of 32-bit floating point pixels, with the cards of h after the
+// mandatory ones.
+func WriteFloat32(w io.Writer, h *Header, width, height int, pix []float32) error {
+	if len(pix) != width*height {
+		return fmt.Errorf("fits: %d pixels for a %dx%d image", len(pix), width, height)
+	}
+	if err := writeHeader(w, imageCards(h, -32, width, height)); err != nil {
+		return err
+	}
+	data := make([]byte, 4*len(pix))
+	for i, v := range pix {
+		binary.BigEndian.PutUint32(data[4*i:], math.Float32bits(v))
+	}
+	return writeData(w, data)
+}
+
+// ReadHeader reads header blocks up to and including the END card and
+// returns the number of bytes consumed.
+func ReadHeader(r io.Reader) (*Header, int64, error) {
+	var h Header
+	var n int64
+	block := make([]byte, BlockSize)
+	for {
+		if _, err := io.ReadFull(r, block); err != nil {
+			return nil, n, fmt.Errorf("fits: reading header: %w", err)
+		}
+		n += BlockSize
+		for i := 0; i < BlockSize; i += cardSize {
+			card := parseCard(string(block[i : i+cardSize]))
+			if card.Key == "END" {
+				return &h, n, nil
+			}
+			if card.Key != "" {
+				h.Cards = append(h.Cards, card)
+			}
+		}
+	}
+}
+
+func parseCard(s string) Card {
+	key := strings.TrimSpace(s[:8])
+	if len(s) < 10 || s[8:10] != "= " {
+		return Card{Key: key, Comment: strings.TrimSpace(s[8:])}
+	}
+	rest := s[10:]
+	var value, comment string
+	if trimmed := strings.TrimLeft(rest, " "); strings.HasPrefix(trimmed, "'") {
+		// Quoted string, '' is an escaped quote
+		end := 1
+		for end < len(trimmed) {
+			if trimmed[end] == '\'' {
+				if end+1 < len(trimmed) && trimmed[end+1] == '\'' {
+					end += 2
+					continue
+				}
+				break
+			}
+			end++
+		}
+		value = trimmed[:end+1]
+		if i := strings.IndexByte(trimmed[end+1:], '/'); i >= 0 {
+			comment = strings.TrimSpace(trimmed[end+2+i:])
+		}
+	} else {
+		value = rest
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			value, comment = rest[:i], strings.TrimSpace(rest[i+1:])
+		}
+	}
+	return Card{Key: key, Value: strings.TrimSpace(value), Comment: comment}
+}
+
+// Image is a 16-bit primary image HDU opened for random frame access.
+type Image struct {
+	Header *Header
+	Width  int
+	Height int
+	Frames int
+
+	f          *os.File
+	bzero      int
+	dataOffset int64
+	frameBytes []byte
+}
+
+// Open opens a FITS file whose primary HDU is a 2D image or 3D cube of
+// 16-bit integers.
+func Open(path string) (*Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	img, err := newImage(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return img, nil
+}
+
+func newImage(f *os.File) (*Image, error) {
+	h, n, err := ReadHeader(bufio.NewReader(io.LimitReader(f, 1<<20)))
+	if err != nil {
+		return nil, err
+	}
+	if bitpix, _ := h.Int("BITPIX"); bitpix != 16 {
+		return nil, fmt.Errorf("fits: unsupported BITPIX %d", bitpix)
+	}
+	naxis, _ := h.Int("NAXIS")
+	if naxis != 2 && naxis != 3 {
+		return nil, fmt.Errorf("fits: unsupported NAXIS %d", naxis)
+	}
+	img := &Image{
+		Header:     h,
+		Frames:     1,
+		f:          f,
+		dataOffset: n,
+	}
+	img.Width, _ = h.Int("NAXIS1")
+	img.Height, _ = h.Int("NAXIS2")
+	if naxis == 3 {
+		img.Frames, _ = h.Int("NAXIS3")
+	}
+	if img.Width <= 0 || img.Height <= 0 || img.Frames <= 0 {
+		return nil, fmt.Errorf("fits: invalid image dimensions")
+	}
+	if bscale, ok := h.Float("BSCALE"); ok && bscale != 1 {
+		return nil, fmt.Errorf("fits: unsupported BSCALE %v", bscale)
+	}
+	bzero, _ := h.Float("BZERO")
+	img.bzero = int(bzero)
+	img.frameBytes = make([]byte, img.Width*img.Height*2)
+	return img, nil
+}
+
+// ReadFrame reads frame i into dst as unsigned pixel values.
+func (img *Image) ReadFrame(i int, dst []uint16) error {
+	if i < 0 || i >= img.Frames {
+		return fmt.Errorf("fits: frame %d out of range", i)
+	}
+	if len(dst) < img.Width*img.Height {
+		return fmt.Errorf("fits: destination too small")
+	}
+	off := img.dataOffset + int64(i)*int64(len(img.frameBytes))
+	if _, err := img.f.ReadAt(img.frameBytes, off); err != nil {
+		return fmt.Errorf("fits: reading frame %d: %w", i, err)
+	}
+	for j := range dst[:img.Width*img.Height] {
+		v := int16(binary.BigEndian.Uint16(img.frameBytes[2*j:]))
+		dst[j] = uint16(int(v) + img.bzero)
+	}
+	return nil
+}
+
+func (img *Image) Close() error {
+	return img.f.Close()
+}
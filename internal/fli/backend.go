@@ -0,0 +1,349 @@
+//go:build flisdk
+
+package fli
+
+/*
+extern void imageReceived(void*, void*);
+*/
+import "C"
+import (
+	"fmt"
+	"runtime/cgo"
+	"strings"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+	"github.com/New-Earth-Lab/flisdk-go/flisdk"
+)
+
+const (
+	RingBufferNumImages = 4
+
+	// The SDK only reports the maximum frame rate
+	minFrameRate = 1.0
+
+	// Sensor cooling setpoint range in degrees Celsius
+	minSensorSetpoint = -40.0
+	maxSensorSetpoint = 20.0
+)
+
+// Compile-time check for Backend.
+var (
+	_ app.CameraBackend       = (*Backend)(nil)
+	_ app.ExposureController  = (*Backend)(nil)
+	_ app.ThermalController   = (*Backend)(nil)
+	_ app.ReadoutModeReporter = (*Backend)(nil)
+	_ app.SensorReporter      = (*Backend)(nil)
+)
+
+// exposureSDK sets the frame rate in Hz and the integration time in
+// seconds. The exposure settings are not supported if the SDK does not
+// provide it.
+type exposureSDK interface {
+	GetFps() (float64, error)
+	GetFpsMax() (float64, error)
+	SetFps(fps float64) error
+	GetTint() (float64, error)
+	GetTintRange() (min, max float64, err error)
+	SetTint(seconds float64) error
+}
+
+// thermalSDK reads the temperatures in degrees Celsius, in the order of the
+// getAllTemp function of the C SDK, and the cooler power in percent, and
+// sets the sensor setpoint. The thermal controls are not supported if the
+// SDK does not provide it.
+type thermalSDK interface {
+	GetAllTemp() (motherboard, frontEnd, powerBoard, sensor, peltier, heatsink float64, err error)
+	GetTecPower() (float64, error)
+	GetSensorTempSetpoint() (float64, error)
+	SetSensorTemp(celsius float64) error
+}
+
+// croppingReader reads back the cropping window the camera applied. The
+// backend falls back to the requested offsets if the SDK does not provide it.
+type croppingReader interface {
+	GetCroppingState() (flisdk.CroppingData, error)
+}
+
+// Backend is an app.CameraBackend driving a camera through the FLI SDK.
+type Backend struct {
+	callbackHandler flisdk.CallbackHandler
+	sdk             *flisdk.FliSdk
+	handler         app.FrameHandler
+	// Optional parts of the SDK, nil where it does not provide them
+	exposure exposureSDK
+	thermal  thermalSDK
+	// counter numbers the frames delivered to the callback. The SDK
+	// image counter cannot be read together with the image, so frames the
+	// grabber drops are not seen as gaps.
+	counter atomic.Uint64
+	// Sensor size measured by Detect
+	sensorWidth, sensorHeight int
+}
+
+func NewBackend() (*Backend, error) {
+	sdk, err := flisdk.Init()
+	if err != nil {
+		return nil, err
+	}
+	b := &Backend{sdk: sdk}
+	b.exposure, _ = any(sdk).(exposureSDK)
+	b.thermal, _ = any(sdk).(thermalSDK)
+	return b, nil
+}
+
+func (b *Backend) Detect(serialNumber string) (string, error) {
+	// Get list of grabbers
+	_, err := b.sdk.DetectGrabbers()
+	if err != nil {
+		return "", err
+	}
+
+	// Get list of cameras
+	cameraStrings, err := b.sdk.DetectCameras()
+	if err != nil {
+		return "", err
+	}
+
+	// Set the camera to the configured model if found
+	for _, cam := range cameraStrings {
+		if strings.Contains(cam, serialNumber) {
+			if err := b.sdk.SetCamera(cam); err != nil {
+				return "", err
+			}
+			return cam, b.measureSensor()
+		}
+	}
+
+	return "", fmt.Errorf("flicamera: Unable to find camera: %s", serialNumber)
+}
+
+// measureSensor reads the sensor size from the image dimensions with
+// cropping disabled.
+func (b *Backend) measureSensor() error {
+	err := b.sdk.SetMode(flisdk.Mode_Full)
+	if err != nil {
+		return err
+	}
+
+	err = b.sdk.Update()
+	if err != nil {
+		return err
+	}
+
+	err = b.sdk.SetCroppingState(flisdk.CroppingData{Enabled: false})
+	if err != nil {
+		return err
+	}
+
+	width, height := b.sdk.GetCurrentImageDimension()
+	if width == 0 || height == 0 {
+		return fmt.Errorf("flicamera: camera reports a %dx%d sensor", width, height)
+	}
+	b.sensorWidth, b.sensorHeight = int(width), int(height)
+	return nil
+}
+
+func (b *Backend) SensorSize() (int, int) {
+	return b.sensorWidth, b.sensorHeight
+}
+
+func (b *Backend) Configure(config app.FliConfig) (app.Geometry, error) {
+	err := b.sdk.SetMode(flisdk.Mode_Full)
+	if err != nil {
+		return app.Geometry{}, err
+	}
+
+	err = b.sdk.Update()
+	if err != nil {
+		return app.Geometry{}, err
+	}
+
+	// Set sensor cropping
+	croppingData := flisdk.CroppingData{
+		Col1:    config.OffsetX,
+		Col2:    config.OffsetX + uint16(config.Width) - 1,
+		Row1:    config.OffsetY,
+		Row2:    config.OffsetY + uint16(config.Height) - 1,
+		Enabled: true,
+	}
+
+	err = b.sdk.SetCroppingState(croppingData)
+	if err != nil {
+		return app.Geometry{}, err
+	}
+
+	// Set the pixel format to unsigned
+	b.sdk.EnableUnsignedPixel(true)
+
+	// Enable the ring buffer to shrink it
+	b.sdk.EnableRingBuffer(true)
+	b.sdk.SetBufferSizeInImages(RingBufferNumImages)
+
+	// Disable the ring buffer
+	b.sdk.EnableRingBuffer(false)
+	b.sdk.SetNumberImagesPerBuffer(1)
+
+	// Get image dimensions for buffer size
+	width, height := b.sdk.GetCurrentImageDimension()
+
+	// Read back the sensor offsets the camera applied
+	crop := croppingData
+	if r, ok := any(b.sdk).(croppingReader); ok {
+		crop, err = r.GetCroppingState()
+		if err != nil {
+			return app.Geometry{}, err
+		}
+	}
+
+	return app.Geometry{
+		Width:            int32(width),
+		Height:           int32(height),
+		OffsetX:          int32(crop.Col1),
+		OffsetY:          int32(crop.Row1),
+		ImageSizeInBytes: int32(b.sdk.GetImageSizeInBytes()),
+	}, nil
+}
+
+func (b *Backend) ReadoutMode() string {
+	return "full"
+}
+
+func (b *Backend) SetFrameHandler(handler app.FrameHandler) {
+	if b.handler != nil {
+		b.sdk.RemoveCallbackNewImage(b.callbackHandler)
+	}
+	b.handler = handler
+	b.callbackHandler = b.sdk.AddCallbackNewImage(
+		(flisdk.NewImageAvailableCallBack)(C.imageReceived),
+		0, true, b)
+}
+
+func (b *Backend) Start() error {
+	return b.sdk.Start()
+}
+
+func (b *Backend) Stop() error {
+	return b.sdk.Stop()
+}
+
+func (b *Backend) Shutdown() error {
+	err := b.sdk.Stop()
+	if err != nil {
+		return err
+	}
+
+	if b.handler != nil {
+		b.sdk.RemoveCallbackNewImage(b.callbackHandler)
+	}
+	b.sdk.Exit()
+
+	return nil
+}
+
+func (b *Backend) FrameRate() (app.LimitedValue, error) {
+	if b.exposure == nil {
+		return app.LimitedValue{}, app.ErrNotSupported
+	}
+	fps, err := b.exposure.GetFps()
+	if err != nil {
+		return app.LimitedValue{}, err
+	}
+	max, err := b.exposure.GetFpsMax()
+	if err != nil {
+		return app.LimitedValue{}, err
+	}
+	return app.LimitedValue{Value: fps, Min: minFrameRate, Max: max}, nil
+}
+
+func (b *Backend) SetFrameRate(fps float64) (app.LimitedValue, error) {
+	if b.exposure == nil {
+		return app.LimitedValue{}, app.ErrNotSupported
+	}
+	if err := b.exposure.SetFps(fps); err != nil {
+		return app.LimitedValue{}, err
+	}
+	// Read back the value the camera accepted
+	return b.FrameRate()
+}
+
+func (b *Backend) IntegrationTime() (app.LimitedValue, error) {
+	if b.exposure == nil {
+		return app.LimitedValue{}, app.ErrNotSupported
+	}
+	tint, err := b.exposure.GetTint()
+	if err != nil {
+		return app.LimitedValue{}, err
+	}
+	min, max, err := b.exposure.GetTintRange()
+	if err != nil {
+		return app.LimitedValue{}, err
+	}
+	return app.LimitedValue{Value: tint, Min: min, Max: max}, nil
+}
+
+func (b *Backend) SetIntegrationTime(seconds float64) (app.LimitedValue, error) {
+	if b.exposure == nil {
+		return app.LimitedValue{}, app.ErrNotSupported
+	}
+	if err := b.exposure.SetTint(seconds); err != nil {
+		return app.LimitedValue{}, err
+	}
+	// Read back the value the camera accepted
+	return b.IntegrationTime()
+}
+
+func (b *Backend) Temperatures() (app.Temperatures, error) {
+	if b.thermal == nil {
+		return app.Temperatures{}, app.ErrNotSupported
+	}
+	motherboard, _, _, sensor, peltier, heatsink, err := b.thermal.GetAllTemp()
+	if err != nil {
+		return app.Temperatures{}, err
+	}
+	power, err := b.thermal.GetTecPower()
+	if err != nil {
+		return app.Temperatures{}, err
+	}
+	return app.Temperatures{
+		Sensor:      sensor,
+		Motherboard: motherboard,
+		Peltier:     peltier,
+		Heatsink:    heatsink,
+		TECPower:    power,
+	}, nil
+}
+
+func (b *Backend) SensorSetpoint() (app.LimitedValue, error) {
+	if b.thermal == nil {
+		return app.LimitedValue{}, app.ErrNotSupported
+	}
+	setpoint, err := b.thermal.GetSensorTempSetpoint()
+	if err != nil {
+		return app.LimitedValue{}, err
+	}
+	return app.LimitedValue{
+		Value: setpoint,
+		Min:   minSensorSetpoint,
+		Max:   maxSensorSetpoint,
+	}, nil
+}
+
+func (b *Backend) SetSensorSetpoint(celsius float64) (app.LimitedValue, error) {
+	if b.thermal == nil {
+		return app.LimitedValue{}, app.ErrNotSupported
+	}
+	if err := b.thermal.SetSensorTemp(celsius); err != nil {
+		return app.LimitedValue{}, err
+	}
+	// Read back the value the camera accepted
+	return b.SensorSetpoint()
+}
+
+//export imageReceived
+//go:nocheckptr go:nosplit
+func imageReceived(image unsafe.Pointer, ctx unsafe.Pointer) {
+	b := (cgo.Handle)(ctx).Value().(*Backend)
+	b.handler(image, b.counter.Add(1))
+}
@@ -0,0 +1,10 @@
+// Package fli drives FLI cameras through the FLI SDK. It requires cgo and
+// the SDK and is only built with the flisdk build tag.
+//
+// Acquisition and cropping use the flisdk-go methods the service has always
+// used. The exposure settings, temperatures and cropping read-back use
+// methods of flisdk.FliSdk that have not been checked against flisdk-go.
+// They are reached through interfaces, so the package builds whether or not
+// the SDK provides them, and the features whose methods are missing report
+// app.ErrNotSupported.
+package fli
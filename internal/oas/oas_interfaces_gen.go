@@ -1,6 +1,6 @@
 // Code generated by ogen, DO NOT EDIT.
 package oas
 
-type GetPetByIdRes interface {
-	getPetByIdRes()
+type GetSnapshotRes interface {
+	getSnapshotRes()
 }
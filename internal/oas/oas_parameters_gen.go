@@ -15,25 +15,311 @@ import (
 	"github.com/ogen-go/ogen/validate"
 )
 
-// GetPetByIdParams is parameters of getPetById operation.
-type GetPetByIdParams struct {
-	// ID of pet to return.
-	PetId int64
+// GetSnapshotParams is parameters of getSnapshot operation.
+type GetSnapshotParams struct {
+	Format OptSnapshotFormat
+	// Number of consecutive frames to average.
+	Frames OptInt32
 }
 
-func unpackGetPetByIdParams(packed middleware.Parameters) (params GetPetByIdParams) {
+func unpackGetSnapshotParams(packed middleware.Parameters) (params GetSnapshotParams) {
 	{
 		key := middleware.ParameterKey{
-			Name: "petId",
+			Name: "format",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Format = v.(OptSnapshotFormat)
+		}
+	}
+	{
+		key := middleware.ParameterKey{
+			Name: "frames",
+			In:   "query",
+		}
+		if v, ok := packed[key]; ok {
+			params.Frames = v.(OptInt32)
+		}
+	}
+	return params
+}
+
+func decodeGetSnapshotParams(args [0]string, r *http.Request) (params GetSnapshotParams, _ error) {
+	q := uri.NewQueryDecoder(r.URL.Query())
+	// Set default value for query: format.
+	{
+		val := SnapshotFormat("fits")
+		params.Format.SetTo(val)
+	}
+	// Decode query: format.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "format",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotFormatVal SnapshotFormat
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToString(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotFormatVal = SnapshotFormat(c)
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Format.SetTo(paramsDotFormatVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if params.Format.Set {
+					if err := func() error {
+						if err := params.Format.Value.Validate(); err != nil {
+							return err
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "format",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	// Set default value for query: frames.
+	{
+		val := int32(1)
+		params.Frames.SetTo(val)
+	}
+	// Decode query: frames.
+	if err := func() error {
+		cfg := uri.QueryParameterDecodingConfig{
+			Name:    "frames",
+			Style:   uri.QueryStyleForm,
+			Explode: true,
+		}
+
+		if err := q.HasParam(cfg); err == nil {
+			if err := q.DecodeParam(cfg, func(d uri.Decoder) error {
+				var paramsDotFramesVal int32
+				if err := func() error {
+					val, err := d.DecodeValue()
+					if err != nil {
+						return err
+					}
+
+					c, err := conv.ToInt32(val)
+					if err != nil {
+						return err
+					}
+
+					paramsDotFramesVal = c
+					return nil
+				}(); err != nil {
+					return err
+				}
+				params.Frames.SetTo(paramsDotFramesVal)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := func() error {
+				if params.Frames.Set {
+					if err := func() error {
+						if err := (validate.Int{
+							MinSet:        true,
+							Min:           1,
+							MaxSet:        true,
+							Max:           1000,
+							MinExclusive:  false,
+							MaxExclusive:  false,
+							MultipleOfSet: false,
+							MultipleOf:    0,
+						}).Validate(int64(params.Frames.Value)); err != nil {
+							return errors.Wrap(err, "int")
+						}
+						return nil
+					}(); err != nil {
+						return err
+					}
+				}
+				return nil
+			}(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "frames",
+			In:   "query",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// StartReplayParams is parameters of startReplay operation.
+type StartReplayParams struct {
+	RecordingId int64
+}
+
+func unpackStartReplayParams(packed middleware.Parameters) (params StartReplayParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "recordingId",
+			In:   "path",
+		}
+		params.RecordingId = packed[key].(int64)
+	}
+	return params
+}
+
+func decodeStartReplayParams(args [1]string, r *http.Request) (params StartReplayParams, _ error) {
+	// Decode path: recordingId.
+	if err := func() error {
+		param, err := url.PathUnescape(args[0])
+		if err != nil {
+			return errors.Wrap(err, "unescape path")
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "recordingId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToInt64(val)
+				if err != nil {
+					return err
+				}
+
+				params.RecordingId = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "recordingId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// StopRecordingParams is parameters of stopRecording operation.
+type StopRecordingParams struct {
+	RecordingId int64
+}
+
+func unpackStopRecordingParams(packed middleware.Parameters) (params StopRecordingParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "recordingId",
+			In:   "path",
+		}
+		params.RecordingId = packed[key].(int64)
+	}
+	return params
+}
+
+func decodeStopRecordingParams(args [1]string, r *http.Request) (params StopRecordingParams, _ error) {
+	// Decode path: recordingId.
+	if err := func() error {
+		param, err := url.PathUnescape(args[0])
+		if err != nil {
+			return errors.Wrap(err, "unescape path")
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "recordingId",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToInt64(val)
+				if err != nil {
+					return err
+				}
+
+				params.RecordingId = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "recordingId",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
+// StopReplayParams is parameters of stopReplay operation.
+type StopReplayParams struct {
+	ReplayId int64
+}
+
+func unpackStopReplayParams(packed middleware.Parameters) (params StopReplayParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "replayId",
 			In:   "path",
 		}
-		params.PetId = packed[key].(int64)
+		params.ReplayId = packed[key].(int64)
 	}
 	return params
 }
 
-func decodeGetPetByIdParams(args [1]string, r *http.Request) (params GetPetByIdParams, _ error) {
-	// Decode path: petId.
+func decodeStopReplayParams(args [1]string, r *http.Request) (params StopReplayParams, _ error) {
+	// Decode path: replayId.
 	if err := func() error {
 		param, err := url.PathUnescape(args[0])
 		if err != nil {
@@ -41,7 +327,7 @@ func decodeGetPetByIdParams(args [1]string, r *http.Request) (params GetPetByIdP
 		}
 		if len(param) > 0 {
 			d := uri.NewPathDecoder(uri.PathDecoderConfig{
-				Param:   "petId",
+				Param:   "replayId",
 				Value:   param,
 				Style:   uri.PathStyleSimple,
 				Explode: false,
@@ -58,7 +344,7 @@ func decodeGetPetByIdParams(args [1]string, r *http.Request) (params GetPetByIdP
 					return err
 				}
 
-				params.PetId = c
+				params.ReplayId = c
 				return nil
 			}(); err != nil {
 				return err
@@ -69,7 +355,7 @@ func decodeGetPetByIdParams(args [1]string, r *http.Request) (params GetPetByIdP
 		return nil
 	}(); err != nil {
 		return params, &ogenerrors.DecodeParamError{
-			Name: "petId",
+			Name: "replayId",
 			In:   "path",
 			Err:  err,
 		}
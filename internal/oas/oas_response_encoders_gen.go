@@ -3,35 +3,653 @@
 package oas
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/go-faster/errors"
 	"github.com/go-faster/jx"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ogen-go/ogen/conv"
+	"github.com/ogen-go/ogen/uri"
 )
 
-func encodeGetPetByIdResponse(response GetPetByIdRes, w http.ResponseWriter, span trace.Span) error {
+func encodeGetAcquisitionResponse(response *AcquisitionState, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetCameraResponse(response *CameraInfo, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetFrameRateResponse(response *LimitedValue, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetGeometryResponse(response *Geometry, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetIntegrationTimeResponse(response *LimitedValue, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetSensorSetpointResponse(response *LimitedValue, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetSnapshotResponse(response GetSnapshotRes, w http.ResponseWriter, span trace.Span) error {
 	switch response := response.(type) {
-	case *Pet:
-		w.Header().Set("Content-Type", "application/json")
+	case *GetSnapshotOKApplicationFitsHeaders:
+		w.Header().Set("Content-Type", "application/fits")
+		// Encoding response headers.
+		{
+			h := uri.NewHeaderEncoder(w.Header())
+			// Encode "X-Frames" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Frames",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XFrames))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Frames header")
+				}
+			}
+			// Encode "X-Height" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Height",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XHeight))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Height header")
+				}
+			}
+			// Encode "X-Offset-X" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Offset-X",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XOffsetX))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Offset-X header")
+				}
+			}
+			// Encode "X-Offset-Y" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Offset-Y",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XOffsetY))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Offset-Y header")
+				}
+			}
+			// Encode "X-Pixel-Format" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Pixel-Format",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.StringToString(response.XPixelFormat))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Pixel-Format header")
+				}
+			}
+			// Encode "X-Sequence-Number" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Sequence-Number",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int64ToString(response.XSequenceNumber))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Sequence-Number header")
+				}
+			}
+			// Encode "X-Timestamp" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Timestamp",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.DateTimeToString(response.XTimestamp))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Timestamp header")
+				}
+			}
+			// Encode "X-Width" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Width",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XWidth))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Width header")
+				}
+			}
+		}
+		w.WriteHeader(200)
+		span.SetStatus(codes.Ok, http.StatusText(200))
+
+		writer := w
+		if _, err := io.Copy(writer, response.Response); err != nil {
+			return errors.Wrap(err, "write")
+		}
+		return nil
+
+	case *GetSnapshotOKApplicationOctetStreamHeaders:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		// Encoding response headers.
+		{
+			h := uri.NewHeaderEncoder(w.Header())
+			// Encode "X-Frames" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Frames",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XFrames))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Frames header")
+				}
+			}
+			// Encode "X-Height" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Height",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XHeight))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Height header")
+				}
+			}
+			// Encode "X-Offset-X" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Offset-X",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XOffsetX))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Offset-X header")
+				}
+			}
+			// Encode "X-Offset-Y" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Offset-Y",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XOffsetY))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Offset-Y header")
+				}
+			}
+			// Encode "X-Pixel-Format" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Pixel-Format",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.StringToString(response.XPixelFormat))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Pixel-Format header")
+				}
+			}
+			// Encode "X-Sequence-Number" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Sequence-Number",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int64ToString(response.XSequenceNumber))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Sequence-Number header")
+				}
+			}
+			// Encode "X-Timestamp" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Timestamp",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.DateTimeToString(response.XTimestamp))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Timestamp header")
+				}
+			}
+			// Encode "X-Width" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Width",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XWidth))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Width header")
+				}
+			}
+		}
 		w.WriteHeader(200)
 		span.SetStatus(codes.Ok, http.StatusText(200))
 
-		e := jx.GetEncoder()
-		response.Encode(e)
-		if _, err := e.WriteTo(w); err != nil {
+		writer := w
+		if _, err := io.Copy(writer, response.Response); err != nil {
 			return errors.Wrap(err, "write")
 		}
 		return nil
 
-	case *GetPetByIdNotFound:
-		w.WriteHeader(404)
-		span.SetStatus(codes.Error, http.StatusText(404))
+	case *GetSnapshotOKImagePNGHeaders:
+		w.Header().Set("Content-Type", "image/png")
+		// Encoding response headers.
+		{
+			h := uri.NewHeaderEncoder(w.Header())
+			// Encode "X-Frames" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Frames",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XFrames))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Frames header")
+				}
+			}
+			// Encode "X-Height" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Height",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XHeight))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Height header")
+				}
+			}
+			// Encode "X-Offset-X" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Offset-X",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XOffsetX))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Offset-X header")
+				}
+			}
+			// Encode "X-Offset-Y" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Offset-Y",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XOffsetY))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Offset-Y header")
+				}
+			}
+			// Encode "X-Pixel-Format" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Pixel-Format",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.StringToString(response.XPixelFormat))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Pixel-Format header")
+				}
+			}
+			// Encode "X-Sequence-Number" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Sequence-Number",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int64ToString(response.XSequenceNumber))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Sequence-Number header")
+				}
+			}
+			// Encode "X-Timestamp" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Timestamp",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.DateTimeToString(response.XTimestamp))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Timestamp header")
+				}
+			}
+			// Encode "X-Width" header.
+			{
+				cfg := uri.HeaderParameterEncodingConfig{
+					Name:    "X-Width",
+					Explode: false,
+				}
+				if err := h.EncodeParam(cfg, func(e uri.Encoder) error {
+					return e.EncodeValue(conv.Int32ToString(response.XWidth))
+				}); err != nil {
+					return errors.Wrap(err, "encode X-Width header")
+				}
+			}
+		}
+		w.WriteHeader(200)
+		span.SetStatus(codes.Ok, http.StatusText(200))
 
+		writer := w
+		if _, err := io.Copy(writer, response.Response); err != nil {
+			return errors.Wrap(err, "write")
+		}
 		return nil
 
 	default:
 		return errors.Errorf("unexpected response type: %T", response)
 	}
 }
+
+func encodeGetStatsResponse(response *PipelineStats, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetTemperatureResponse(response *ThermalStatus, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeGetTransportResponse(response *TransportStatus, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeListRecordingsResponse(response []Recording, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	e.ArrStart()
+	for _, elem := range response {
+		elem.Encode(e)
+	}
+	e.ArrEnd()
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeListReplaysResponse(response []Replay, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	e.ArrStart()
+	for _, elem := range response {
+		elem.Encode(e)
+	}
+	e.ArrEnd()
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeSetFrameRateResponse(response *LimitedValue, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeSetIntegrationTimeResponse(response *LimitedValue, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeSetROIResponse(response *Geometry, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeSetSensorSetpointResponse(response *LimitedValue, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeStartAcquisitionResponse(response *AcquisitionState, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeStartRecordingResponse(response *Recording, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeStartReplayResponse(response *Replay, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeStopAcquisitionResponse(response *AcquisitionState, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeStopRecordingResponse(response *Recording, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := jx.GetEncoder()
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+}
+
+func encodeStopReplayResponse(response *StopReplayNoContent, w http.ResponseWriter, span trace.Span) error {
+	w.WriteHeader(204)
+	span.SetStatus(codes.Ok, http.StatusText(204))
+
+	return nil
+}
+
+func encodeErrorResponse(response *ErrorStatusCode, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json")
+	code := response.StatusCode
+	if code == 0 {
+		// Set default status code.
+		code = http.StatusOK
+	}
+	w.WriteHeader(code)
+	st := http.StatusText(code)
+	if code >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, st)
+	} else {
+		span.SetStatus(codes.Ok, st)
+	}
+
+	e := jx.GetEncoder()
+	response.Response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+	return nil
+
+}
@@ -42,30 +42,442 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			break
 		}
 		switch elem[0] {
-		case '/': // Prefix: "/pet/"
-			if l := len("/pet/"); len(elem) >= l && elem[0:l] == "/pet/" {
+		case '/': // Prefix: "/"
+			if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 				elem = elem[l:]
 			} else {
 				break
 			}
 
-			// Param: "petId"
-			// Leaf parameter
-			args[0] = elem
-			elem = ""
-
 			if len(elem) == 0 {
-				// Leaf node.
-				switch r.Method {
-				case "GET":
-					s.handleGetPetByIdRequest([1]string{
-						args[0],
-					}, w, r)
-				default:
-					s.notAllowed(w, r, "GET")
+				break
+			}
+			switch elem[0] {
+			case 'a': // Prefix: "a"
+				if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
+					elem = elem[l:]
+				} else {
+					break
 				}
 
-				return
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case 'e': // Prefix: "eron"
+					if l := len("eron"); len(elem) >= l && elem[0:l] == "eron" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						// Leaf node.
+						switch r.Method {
+						case "GET":
+							s.handleGetTransportRequest([0]string{}, w, r)
+						default:
+							s.notAllowed(w, r, "GET")
+						}
+
+						return
+					}
+				case 'r': // Prefix: "rchive/re"
+					if l := len("rchive/re"); len(elem) >= l && elem[0:l] == "rchive/re" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "cordings"
+						if l := len("cordings"); len(elem) >= l && elem[0:l] == "cordings" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "GET":
+								s.handleListRecordingsRequest([0]string{}, w, r)
+							case "POST":
+								s.handleStartRecordingRequest([0]string{}, w, r)
+							default:
+								s.notAllowed(w, r, "GET,POST")
+							}
+
+							return
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "recordingId"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
+							}
+							args[0] = elem[:idx]
+							elem = elem[idx:]
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'r': // Prefix: "replay"
+									if l := len("replay"); len(elem) >= l && elem[0:l] == "replay" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "POST":
+											s.handleStartReplayRequest([1]string{
+												args[0],
+											}, w, r)
+										default:
+											s.notAllowed(w, r, "POST")
+										}
+
+										return
+									}
+								case 's': // Prefix: "stop"
+									if l := len("stop"); len(elem) >= l && elem[0:l] == "stop" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										// Leaf node.
+										switch r.Method {
+										case "POST":
+											s.handleStopRecordingRequest([1]string{
+												args[0],
+											}, w, r)
+										default:
+											s.notAllowed(w, r, "POST")
+										}
+
+										return
+									}
+								}
+							}
+						}
+					case 'p': // Prefix: "plays"
+						if l := len("plays"); len(elem) >= l && elem[0:l] == "plays" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "GET":
+								s.handleListReplaysRequest([0]string{}, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "replayId"
+							// Leaf parameter
+							args[0] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "DELETE":
+									s.handleStopReplayRequest([1]string{
+										args[0],
+									}, w, r)
+								default:
+									s.notAllowed(w, r, "DELETE")
+								}
+
+								return
+							}
+						}
+					}
+				}
+			case 'c': // Prefix: "camera"
+				if l := len("camera"); len(elem) >= l && elem[0:l] == "camera" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch r.Method {
+					case "GET":
+						s.handleGetCameraRequest([0]string{}, w, r)
+					default:
+						s.notAllowed(w, r, "GET")
+					}
+
+					return
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "acquisition"
+						if l := len("acquisition"); len(elem) >= l && elem[0:l] == "acquisition" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "GET":
+								s.handleGetAcquisitionRequest([0]string{}, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/st"
+							if l := len("/st"); len(elem) >= l && elem[0:l] == "/st" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'a': // Prefix: "art"
+								if l := len("art"); len(elem) >= l && elem[0:l] == "art" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "POST":
+										s.handleStartAcquisitionRequest([0]string{}, w, r)
+									default:
+										s.notAllowed(w, r, "POST")
+									}
+
+									return
+								}
+							case 'o': // Prefix: "op"
+								if l := len("op"); len(elem) >= l && elem[0:l] == "op" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									// Leaf node.
+									switch r.Method {
+									case "POST":
+										s.handleStopAcquisitionRequest([0]string{}, w, r)
+									default:
+										s.notAllowed(w, r, "POST")
+									}
+
+									return
+								}
+							}
+						}
+					case 'f': // Prefix: "frame-rate"
+						if l := len("frame-rate"); len(elem) >= l && elem[0:l] == "frame-rate" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetFrameRateRequest([0]string{}, w, r)
+							case "PUT":
+								s.handleSetFrameRateRequest([0]string{}, w, r)
+							default:
+								s.notAllowed(w, r, "GET,PUT")
+							}
+
+							return
+						}
+					case 'g': // Prefix: "geometry"
+						if l := len("geometry"); len(elem) >= l && elem[0:l] == "geometry" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetGeometryRequest([0]string{}, w, r)
+							case "PUT":
+								s.handleSetROIRequest([0]string{}, w, r)
+							default:
+								s.notAllowed(w, r, "GET,PUT")
+							}
+
+							return
+						}
+					case 'i': // Prefix: "integration-time"
+						if l := len("integration-time"); len(elem) >= l && elem[0:l] == "integration-time" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							// Leaf node.
+							switch r.Method {
+							case "GET":
+								s.handleGetIntegrationTimeRequest([0]string{}, w, r)
+							case "PUT":
+								s.handleSetIntegrationTimeRequest([0]string{}, w, r)
+							default:
+								s.notAllowed(w, r, "GET,PUT")
+							}
+
+							return
+						}
+					case 's': // Prefix: "s"
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'n': // Prefix: "napshot"
+							if l := len("napshot"); len(elem) >= l && elem[0:l] == "napshot" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetSnapshotRequest([0]string{}, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+						case 't': // Prefix: "tats"
+							if l := len("tats"); len(elem) >= l && elem[0:l] == "tats" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetStatsRequest([0]string{}, w, r)
+								default:
+									s.notAllowed(w, r, "GET")
+								}
+
+								return
+							}
+						}
+					case 't': // Prefix: "temperature"
+						if l := len("temperature"); len(elem) >= l && elem[0:l] == "temperature" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch r.Method {
+							case "GET":
+								s.handleGetTemperatureRequest([0]string{}, w, r)
+							default:
+								s.notAllowed(w, r, "GET")
+							}
+
+							return
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/setpoint"
+							if l := len("/setpoint"); len(elem) >= l && elem[0:l] == "/setpoint" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								// Leaf node.
+								switch r.Method {
+								case "GET":
+									s.handleGetSensorSetpointRequest([0]string{}, w, r)
+								case "PUT":
+									s.handleSetSensorSetpointRequest([0]string{}, w, r)
+								default:
+									s.notAllowed(w, r, "GET,PUT")
+								}
+
+								return
+							}
+						}
+					}
+				}
 			}
 		}
 	}
@@ -136,30 +548,515 @@ func (s *Server) FindPath(method string, u *url.URL) (r Route, _ bool) {
 			break
 		}
 		switch elem[0] {
-		case '/': // Prefix: "/pet/"
-			if l := len("/pet/"); len(elem) >= l && elem[0:l] == "/pet/" {
+		case '/': // Prefix: "/"
+			if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
 				elem = elem[l:]
 			} else {
 				break
 			}
 
-			// Param: "petId"
-			// Leaf parameter
-			args[0] = elem
-			elem = ""
-
 			if len(elem) == 0 {
-				switch method {
-				case "GET":
-					// Leaf: GetPetById
-					r.name = "GetPetById"
-					r.operationID = "getPetById"
-					r.pathPattern = "/pet/{petId}"
-					r.args = args
-					r.count = 1
-					return r, true
-				default:
-					return
+				break
+			}
+			switch elem[0] {
+			case 'a': // Prefix: "a"
+				if l := len("a"); len(elem) >= l && elem[0:l] == "a" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					break
+				}
+				switch elem[0] {
+				case 'e': // Prefix: "eron"
+					if l := len("eron"); len(elem) >= l && elem[0:l] == "eron" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						switch method {
+						case "GET":
+							// Leaf: GetTransport
+							r.name = "GetTransport"
+							r.operationID = "getTransport"
+							r.pathPattern = "/aeron"
+							r.args = args
+							r.count = 0
+							return r, true
+						default:
+							return
+						}
+					}
+				case 'r': // Prefix: "rchive/re"
+					if l := len("rchive/re"); len(elem) >= l && elem[0:l] == "rchive/re" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'c': // Prefix: "cordings"
+						if l := len("cordings"); len(elem) >= l && elem[0:l] == "cordings" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = "ListRecordings"
+								r.operationID = "listRecordings"
+								r.pathPattern = "/archive/recordings"
+								r.args = args
+								r.count = 0
+								return r, true
+							case "POST":
+								r.name = "StartRecording"
+								r.operationID = "startRecording"
+								r.pathPattern = "/archive/recordings"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "recordingId"
+							// Match until "/"
+							idx := strings.IndexByte(elem, '/')
+							if idx < 0 {
+								idx = len(elem)
+							}
+							args[0] = elem[:idx]
+							elem = elem[idx:]
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case '/': // Prefix: "/"
+								if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									break
+								}
+								switch elem[0] {
+								case 'r': // Prefix: "replay"
+									if l := len("replay"); len(elem) >= l && elem[0:l] == "replay" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch method {
+										case "POST":
+											// Leaf: StartReplay
+											r.name = "StartReplay"
+											r.operationID = "startReplay"
+											r.pathPattern = "/archive/recordings/{recordingId}/replay"
+											r.args = args
+											r.count = 1
+											return r, true
+										default:
+											return
+										}
+									}
+								case 's': // Prefix: "stop"
+									if l := len("stop"); len(elem) >= l && elem[0:l] == "stop" {
+										elem = elem[l:]
+									} else {
+										break
+									}
+
+									if len(elem) == 0 {
+										switch method {
+										case "POST":
+											// Leaf: StopRecording
+											r.name = "StopRecording"
+											r.operationID = "stopRecording"
+											r.pathPattern = "/archive/recordings/{recordingId}/stop"
+											r.args = args
+											r.count = 1
+											return r, true
+										default:
+											return
+										}
+									}
+								}
+							}
+						}
+					case 'p': // Prefix: "plays"
+						if l := len("plays"); len(elem) >= l && elem[0:l] == "plays" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = "ListReplays"
+								r.operationID = "listReplays"
+								r.pathPattern = "/archive/replays"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/"
+							if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							// Param: "replayId"
+							// Leaf parameter
+							args[0] = elem
+							elem = ""
+
+							if len(elem) == 0 {
+								switch method {
+								case "DELETE":
+									// Leaf: StopReplay
+									r.name = "StopReplay"
+									r.operationID = "stopReplay"
+									r.pathPattern = "/archive/replays/{replayId}"
+									r.args = args
+									r.count = 1
+									return r, true
+								default:
+									return
+								}
+							}
+						}
+					}
+				}
+			case 'c': // Prefix: "camera"
+				if l := len("camera"); len(elem) >= l && elem[0:l] == "camera" {
+					elem = elem[l:]
+				} else {
+					break
+				}
+
+				if len(elem) == 0 {
+					switch method {
+					case "GET":
+						r.name = "GetCamera"
+						r.operationID = "getCamera"
+						r.pathPattern = "/camera"
+						r.args = args
+						r.count = 0
+						return r, true
+					default:
+						return
+					}
+				}
+				switch elem[0] {
+				case '/': // Prefix: "/"
+					if l := len("/"); len(elem) >= l && elem[0:l] == "/" {
+						elem = elem[l:]
+					} else {
+						break
+					}
+
+					if len(elem) == 0 {
+						break
+					}
+					switch elem[0] {
+					case 'a': // Prefix: "acquisition"
+						if l := len("acquisition"); len(elem) >= l && elem[0:l] == "acquisition" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = "GetAcquisition"
+								r.operationID = "getAcquisition"
+								r.pathPattern = "/camera/acquisition"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/st"
+							if l := len("/st"); len(elem) >= l && elem[0:l] == "/st" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								break
+							}
+							switch elem[0] {
+							case 'a': // Prefix: "art"
+								if l := len("art"); len(elem) >= l && elem[0:l] == "art" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch method {
+									case "POST":
+										// Leaf: StartAcquisition
+										r.name = "StartAcquisition"
+										r.operationID = "startAcquisition"
+										r.pathPattern = "/camera/acquisition/start"
+										r.args = args
+										r.count = 0
+										return r, true
+									default:
+										return
+									}
+								}
+							case 'o': // Prefix: "op"
+								if l := len("op"); len(elem) >= l && elem[0:l] == "op" {
+									elem = elem[l:]
+								} else {
+									break
+								}
+
+								if len(elem) == 0 {
+									switch method {
+									case "POST":
+										// Leaf: StopAcquisition
+										r.name = "StopAcquisition"
+										r.operationID = "stopAcquisition"
+										r.pathPattern = "/camera/acquisition/stop"
+										r.args = args
+										r.count = 0
+										return r, true
+									default:
+										return
+									}
+								}
+							}
+						}
+					case 'f': // Prefix: "frame-rate"
+						if l := len("frame-rate"); len(elem) >= l && elem[0:l] == "frame-rate" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								// Leaf: GetFrameRate
+								r.name = "GetFrameRate"
+								r.operationID = "getFrameRate"
+								r.pathPattern = "/camera/frame-rate"
+								r.args = args
+								r.count = 0
+								return r, true
+							case "PUT":
+								// Leaf: SetFrameRate
+								r.name = "SetFrameRate"
+								r.operationID = "setFrameRate"
+								r.pathPattern = "/camera/frame-rate"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+					case 'g': // Prefix: "geometry"
+						if l := len("geometry"); len(elem) >= l && elem[0:l] == "geometry" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								// Leaf: GetGeometry
+								r.name = "GetGeometry"
+								r.operationID = "getGeometry"
+								r.pathPattern = "/camera/geometry"
+								r.args = args
+								r.count = 0
+								return r, true
+							case "PUT":
+								// Leaf: SetROI
+								r.name = "SetROI"
+								r.operationID = "setROI"
+								r.pathPattern = "/camera/geometry"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+					case 'i': // Prefix: "integration-time"
+						if l := len("integration-time"); len(elem) >= l && elem[0:l] == "integration-time" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								// Leaf: GetIntegrationTime
+								r.name = "GetIntegrationTime"
+								r.operationID = "getIntegrationTime"
+								r.pathPattern = "/camera/integration-time"
+								r.args = args
+								r.count = 0
+								return r, true
+							case "PUT":
+								// Leaf: SetIntegrationTime
+								r.name = "SetIntegrationTime"
+								r.operationID = "setIntegrationTime"
+								r.pathPattern = "/camera/integration-time"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+					case 's': // Prefix: "s"
+						if l := len("s"); len(elem) >= l && elem[0:l] == "s" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							break
+						}
+						switch elem[0] {
+						case 'n': // Prefix: "napshot"
+							if l := len("napshot"); len(elem) >= l && elem[0:l] == "napshot" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "GET":
+									// Leaf: GetSnapshot
+									r.name = "GetSnapshot"
+									r.operationID = "getSnapshot"
+									r.pathPattern = "/camera/snapshot"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+						case 't': // Prefix: "tats"
+							if l := len("tats"); len(elem) >= l && elem[0:l] == "tats" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "GET":
+									// Leaf: GetStats
+									r.name = "GetStats"
+									r.operationID = "getStats"
+									r.pathPattern = "/camera/stats"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+						}
+					case 't': // Prefix: "temperature"
+						if l := len("temperature"); len(elem) >= l && elem[0:l] == "temperature" {
+							elem = elem[l:]
+						} else {
+							break
+						}
+
+						if len(elem) == 0 {
+							switch method {
+							case "GET":
+								r.name = "GetTemperature"
+								r.operationID = "getTemperature"
+								r.pathPattern = "/camera/temperature"
+								r.args = args
+								r.count = 0
+								return r, true
+							default:
+								return
+							}
+						}
+						switch elem[0] {
+						case '/': // Prefix: "/setpoint"
+							if l := len("/setpoint"); len(elem) >= l && elem[0:l] == "/setpoint" {
+								elem = elem[l:]
+							} else {
+								break
+							}
+
+							if len(elem) == 0 {
+								switch method {
+								case "GET":
+									// Leaf: GetSensorSetpoint
+									r.name = "GetSensorSetpoint"
+									r.operationID = "getSensorSetpoint"
+									r.pathPattern = "/camera/temperature/setpoint"
+									r.args = args
+									r.count = 0
+									return r, true
+								case "PUT":
+									// Leaf: SetSensorSetpoint
+									r.name = "SetSensorSetpoint"
+									r.operationID = "setSensorSetpoint"
+									r.pathPattern = "/camera/temperature/setpoint"
+									r.args = args
+									r.count = 0
+									return r, true
+								default:
+									return
+								}
+							}
+						}
+					}
 				}
 			}
 		}
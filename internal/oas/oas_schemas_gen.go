@@ -3,46 +3,628 @@
 package oas
 
 import (
+	"fmt"
+	"io"
+	"time"
+
 	"github.com/go-faster/errors"
 )
 
-// GetPetByIdNotFound is response for GetPetById operation.
-type GetPetByIdNotFound struct{}
+func (s *ErrorStatusCode) Error() string {
+	return fmt.Sprintf("code %d: %+v", s.StatusCode, s.Response)
+}
+
+// Ref: #/components/schemas/AcquisitionState
+type AcquisitionState struct {
+	Acquiring bool `json:"acquiring"`
+	// Acquisition is stopped until an output has subscribers.
+	Paused bool `json:"paused"`
+}
+
+// GetAcquiring returns the value of Acquiring.
+func (s *AcquisitionState) GetAcquiring() bool {
+	return s.Acquiring
+}
+
+// GetPaused returns the value of Paused.
+func (s *AcquisitionState) GetPaused() bool {
+	return s.Paused
+}
+
+// SetAcquiring sets the value of Acquiring.
+func (s *AcquisitionState) SetAcquiring(val bool) {
+	s.Acquiring = val
+}
+
+// SetPaused sets the value of Paused.
+func (s *AcquisitionState) SetPaused(val bool) {
+	s.Paused = val
+}
+
+// Ref: #/components/schemas/BackPressurePolicy
+type BackPressurePolicy string
+
+const (
+	BackPressurePolicyDropNewest BackPressurePolicy = "drop-newest"
+	BackPressurePolicySpin       BackPressurePolicy = "spin"
+	BackPressurePolicyBlock      BackPressurePolicy = "block"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s BackPressurePolicy) MarshalText() ([]byte, error) {
+	switch s {
+	case BackPressurePolicyDropNewest:
+		return []byte(s), nil
+	case BackPressurePolicySpin:
+		return []byte(s), nil
+	case BackPressurePolicyBlock:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *BackPressurePolicy) UnmarshalText(data []byte) error {
+	switch BackPressurePolicy(data) {
+	case BackPressurePolicyDropNewest:
+		*s = BackPressurePolicyDropNewest
+		return nil
+	case BackPressurePolicySpin:
+		*s = BackPressurePolicySpin
+		return nil
+	case BackPressurePolicyBlock:
+		*s = BackPressurePolicyBlock
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/CameraInfo
+type CameraInfo struct {
+	SerialNumber string `json:"serialNumber"`
+	// Camera description reported by the backend.
+	Model string `json:"model"`
+}
+
+// GetSerialNumber returns the value of SerialNumber.
+func (s *CameraInfo) GetSerialNumber() string {
+	return s.SerialNumber
+}
+
+// GetModel returns the value of Model.
+func (s *CameraInfo) GetModel() string {
+	return s.Model
+}
+
+// SetSerialNumber sets the value of SerialNumber.
+func (s *CameraInfo) SetSerialNumber(val string) {
+	s.SerialNumber = val
+}
+
+// SetModel sets the value of Model.
+func (s *CameraInfo) SetModel(val string) {
+	s.Model = val
+}
+
+// Ref: #/components/schemas/Error
+type Error struct {
+	Message string `json:"message"`
+}
+
+// GetMessage returns the value of Message.
+func (s *Error) GetMessage() string {
+	return s.Message
+}
+
+// SetMessage sets the value of Message.
+func (s *Error) SetMessage(val string) {
+	s.Message = val
+}
+
+// ErrorStatusCode wraps Error with StatusCode.
+type ErrorStatusCode struct {
+	StatusCode int
+	Response   Error
+}
+
+// GetStatusCode returns the value of StatusCode.
+func (s *ErrorStatusCode) GetStatusCode() int {
+	return s.StatusCode
+}
+
+// GetResponse returns the value of Response.
+func (s *ErrorStatusCode) GetResponse() Error {
+	return s.Response
+}
+
+// SetStatusCode sets the value of StatusCode.
+func (s *ErrorStatusCode) SetStatusCode(val int) {
+	s.StatusCode = val
+}
+
+// SetResponse sets the value of Response.
+func (s *ErrorStatusCode) SetResponse(val Error) {
+	s.Response = val
+}
+
+// Ref: #/components/schemas/Geometry
+type Geometry struct {
+	Width          int32 `json:"width"`
+	Height         int32 `json:"height"`
+	OffsetX        int32 `json:"offsetX"`
+	OffsetY        int32 `json:"offsetY"`
+	ImageSizeBytes int32 `json:"imageSizeBytes"`
+}
+
+// GetWidth returns the value of Width.
+func (s *Geometry) GetWidth() int32 {
+	return s.Width
+}
+
+// GetHeight returns the value of Height.
+func (s *Geometry) GetHeight() int32 {
+	return s.Height
+}
+
+// GetOffsetX returns the value of OffsetX.
+func (s *Geometry) GetOffsetX() int32 {
+	return s.OffsetX
+}
+
+// GetOffsetY returns the value of OffsetY.
+func (s *Geometry) GetOffsetY() int32 {
+	return s.OffsetY
+}
+
+// GetImageSizeBytes returns the value of ImageSizeBytes.
+func (s *Geometry) GetImageSizeBytes() int32 {
+	return s.ImageSizeBytes
+}
+
+// SetWidth sets the value of Width.
+func (s *Geometry) SetWidth(val int32) {
+	s.Width = val
+}
+
+// SetHeight sets the value of Height.
+func (s *Geometry) SetHeight(val int32) {
+	s.Height = val
+}
+
+// SetOffsetX sets the value of OffsetX.
+func (s *Geometry) SetOffsetX(val int32) {
+	s.OffsetX = val
+}
+
+// SetOffsetY sets the value of OffsetY.
+func (s *Geometry) SetOffsetY(val int32) {
+	s.OffsetY = val
+}
+
+// SetImageSizeBytes sets the value of ImageSizeBytes.
+func (s *Geometry) SetImageSizeBytes(val int32) {
+	s.ImageSizeBytes = val
+}
+
+type GetSnapshotOKApplicationFits struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetSnapshotOKApplicationFits) Read(p []byte) (n int, err error) {
+	return s.Data.Read(p)
+}
+
+// GetSnapshotOKApplicationFitsHeaders wraps GetSnapshotOKApplicationFits with response headers.
+type GetSnapshotOKApplicationFitsHeaders struct {
+	XFrames         int32
+	XHeight         int32
+	XOffsetX        int32
+	XOffsetY        int32
+	XPixelFormat    string
+	XSequenceNumber int64
+	XTimestamp      time.Time
+	XWidth          int32
+	Response        GetSnapshotOKApplicationFits
+}
+
+// GetXFrames returns the value of XFrames.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXFrames() int32 {
+	return s.XFrames
+}
+
+// GetXHeight returns the value of XHeight.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXHeight() int32 {
+	return s.XHeight
+}
+
+// GetXOffsetX returns the value of XOffsetX.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXOffsetX() int32 {
+	return s.XOffsetX
+}
+
+// GetXOffsetY returns the value of XOffsetY.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXOffsetY() int32 {
+	return s.XOffsetY
+}
+
+// GetXPixelFormat returns the value of XPixelFormat.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXPixelFormat() string {
+	return s.XPixelFormat
+}
+
+// GetXSequenceNumber returns the value of XSequenceNumber.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXSequenceNumber() int64 {
+	return s.XSequenceNumber
+}
+
+// GetXTimestamp returns the value of XTimestamp.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXTimestamp() time.Time {
+	return s.XTimestamp
+}
+
+// GetXWidth returns the value of XWidth.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetXWidth() int32 {
+	return s.XWidth
+}
+
+// GetResponse returns the value of Response.
+func (s *GetSnapshotOKApplicationFitsHeaders) GetResponse() GetSnapshotOKApplicationFits {
+	return s.Response
+}
+
+// SetXFrames sets the value of XFrames.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXFrames(val int32) {
+	s.XFrames = val
+}
+
+// SetXHeight sets the value of XHeight.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXHeight(val int32) {
+	s.XHeight = val
+}
+
+// SetXOffsetX sets the value of XOffsetX.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXOffsetX(val int32) {
+	s.XOffsetX = val
+}
+
+// SetXOffsetY sets the value of XOffsetY.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXOffsetY(val int32) {
+	s.XOffsetY = val
+}
+
+// SetXPixelFormat sets the value of XPixelFormat.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXPixelFormat(val string) {
+	s.XPixelFormat = val
+}
+
+// SetXSequenceNumber sets the value of XSequenceNumber.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXSequenceNumber(val int64) {
+	s.XSequenceNumber = val
+}
+
+// SetXTimestamp sets the value of XTimestamp.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXTimestamp(val time.Time) {
+	s.XTimestamp = val
+}
+
+// SetXWidth sets the value of XWidth.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetXWidth(val int32) {
+	s.XWidth = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetSnapshotOKApplicationFitsHeaders) SetResponse(val GetSnapshotOKApplicationFits) {
+	s.Response = val
+}
+
+func (*GetSnapshotOKApplicationFitsHeaders) getSnapshotRes() {}
+
+type GetSnapshotOKApplicationOctetStream struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetSnapshotOKApplicationOctetStream) Read(p []byte) (n int, err error) {
+	return s.Data.Read(p)
+}
+
+// GetSnapshotOKApplicationOctetStreamHeaders wraps GetSnapshotOKApplicationOctetStream with response headers.
+type GetSnapshotOKApplicationOctetStreamHeaders struct {
+	XFrames         int32
+	XHeight         int32
+	XOffsetX        int32
+	XOffsetY        int32
+	XPixelFormat    string
+	XSequenceNumber int64
+	XTimestamp      time.Time
+	XWidth          int32
+	Response        GetSnapshotOKApplicationOctetStream
+}
+
+// GetXFrames returns the value of XFrames.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXFrames() int32 {
+	return s.XFrames
+}
+
+// GetXHeight returns the value of XHeight.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXHeight() int32 {
+	return s.XHeight
+}
+
+// GetXOffsetX returns the value of XOffsetX.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXOffsetX() int32 {
+	return s.XOffsetX
+}
+
+// GetXOffsetY returns the value of XOffsetY.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXOffsetY() int32 {
+	return s.XOffsetY
+}
+
+// GetXPixelFormat returns the value of XPixelFormat.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXPixelFormat() string {
+	return s.XPixelFormat
+}
+
+// GetXSequenceNumber returns the value of XSequenceNumber.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXSequenceNumber() int64 {
+	return s.XSequenceNumber
+}
+
+// GetXTimestamp returns the value of XTimestamp.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXTimestamp() time.Time {
+	return s.XTimestamp
+}
+
+// GetXWidth returns the value of XWidth.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetXWidth() int32 {
+	return s.XWidth
+}
+
+// GetResponse returns the value of Response.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) GetResponse() GetSnapshotOKApplicationOctetStream {
+	return s.Response
+}
+
+// SetXFrames sets the value of XFrames.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXFrames(val int32) {
+	s.XFrames = val
+}
+
+// SetXHeight sets the value of XHeight.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXHeight(val int32) {
+	s.XHeight = val
+}
+
+// SetXOffsetX sets the value of XOffsetX.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXOffsetX(val int32) {
+	s.XOffsetX = val
+}
+
+// SetXOffsetY sets the value of XOffsetY.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXOffsetY(val int32) {
+	s.XOffsetY = val
+}
+
+// SetXPixelFormat sets the value of XPixelFormat.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXPixelFormat(val string) {
+	s.XPixelFormat = val
+}
+
+// SetXSequenceNumber sets the value of XSequenceNumber.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXSequenceNumber(val int64) {
+	s.XSequenceNumber = val
+}
+
+// SetXTimestamp sets the value of XTimestamp.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXTimestamp(val time.Time) {
+	s.XTimestamp = val
+}
+
+// SetXWidth sets the value of XWidth.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetXWidth(val int32) {
+	s.XWidth = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetSnapshotOKApplicationOctetStreamHeaders) SetResponse(val GetSnapshotOKApplicationOctetStream) {
+	s.Response = val
+}
+
+func (*GetSnapshotOKApplicationOctetStreamHeaders) getSnapshotRes() {}
+
+type GetSnapshotOKImagePNG struct {
+	Data io.Reader
+}
+
+// Read reads data from the Data reader.
+//
+// Kept to satisfy the io.Reader interface.
+func (s GetSnapshotOKImagePNG) Read(p []byte) (n int, err error) {
+	return s.Data.Read(p)
+}
+
+// GetSnapshotOKImagePNGHeaders wraps GetSnapshotOKImagePNG with response headers.
+type GetSnapshotOKImagePNGHeaders struct {
+	XFrames         int32
+	XHeight         int32
+	XOffsetX        int32
+	XOffsetY        int32
+	XPixelFormat    string
+	XSequenceNumber int64
+	XTimestamp      time.Time
+	XWidth          int32
+	Response        GetSnapshotOKImagePNG
+}
+
+// GetXFrames returns the value of XFrames.
+func (s *GetSnapshotOKImagePNGHeaders) GetXFrames() int32 {
+	return s.XFrames
+}
+
+// GetXHeight returns the value of XHeight.
+func (s *GetSnapshotOKImagePNGHeaders) GetXHeight() int32 {
+	return s.XHeight
+}
+
+// GetXOffsetX returns the value of XOffsetX.
+func (s *GetSnapshotOKImagePNGHeaders) GetXOffsetX() int32 {
+	return s.XOffsetX
+}
+
+// GetXOffsetY returns the value of XOffsetY.
+func (s *GetSnapshotOKImagePNGHeaders) GetXOffsetY() int32 {
+	return s.XOffsetY
+}
+
+// GetXPixelFormat returns the value of XPixelFormat.
+func (s *GetSnapshotOKImagePNGHeaders) GetXPixelFormat() string {
+	return s.XPixelFormat
+}
+
+// GetXSequenceNumber returns the value of XSequenceNumber.
+func (s *GetSnapshotOKImagePNGHeaders) GetXSequenceNumber() int64 {
+	return s.XSequenceNumber
+}
+
+// GetXTimestamp returns the value of XTimestamp.
+func (s *GetSnapshotOKImagePNGHeaders) GetXTimestamp() time.Time {
+	return s.XTimestamp
+}
+
+// GetXWidth returns the value of XWidth.
+func (s *GetSnapshotOKImagePNGHeaders) GetXWidth() int32 {
+	return s.XWidth
+}
+
+// GetResponse returns the value of Response.
+func (s *GetSnapshotOKImagePNGHeaders) GetResponse() GetSnapshotOKImagePNG {
+	return s.Response
+}
+
+// SetXFrames sets the value of XFrames.
+func (s *GetSnapshotOKImagePNGHeaders) SetXFrames(val int32) {
+	s.XFrames = val
+}
+
+// SetXHeight sets the value of XHeight.
+func (s *GetSnapshotOKImagePNGHeaders) SetXHeight(val int32) {
+	s.XHeight = val
+}
+
+// SetXOffsetX sets the value of XOffsetX.
+func (s *GetSnapshotOKImagePNGHeaders) SetXOffsetX(val int32) {
+	s.XOffsetX = val
+}
+
+// SetXOffsetY sets the value of XOffsetY.
+func (s *GetSnapshotOKImagePNGHeaders) SetXOffsetY(val int32) {
+	s.XOffsetY = val
+}
+
+// SetXPixelFormat sets the value of XPixelFormat.
+func (s *GetSnapshotOKImagePNGHeaders) SetXPixelFormat(val string) {
+	s.XPixelFormat = val
+}
+
+// SetXSequenceNumber sets the value of XSequenceNumber.
+func (s *GetSnapshotOKImagePNGHeaders) SetXSequenceNumber(val int64) {
+	s.XSequenceNumber = val
+}
+
+// SetXTimestamp sets the value of XTimestamp.
+func (s *GetSnapshotOKImagePNGHeaders) SetXTimestamp(val time.Time) {
+	s.XTimestamp = val
+}
+
+// SetXWidth sets the value of XWidth.
+func (s *GetSnapshotOKImagePNGHeaders) SetXWidth(val int32) {
+	s.XWidth = val
+}
+
+// SetResponse sets the value of Response.
+func (s *GetSnapshotOKImagePNGHeaders) SetResponse(val GetSnapshotOKImagePNG) {
+	s.Response = val
+}
+
+func (*GetSnapshotOKImagePNGHeaders) getSnapshotRes() {}
+
+// Ref: #/components/schemas/LimitedValue
+type LimitedValue struct {
+	Value float64 `json:"value"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+}
+
+// GetValue returns the value of Value.
+func (s *LimitedValue) GetValue() float64 {
+	return s.Value
+}
+
+// GetMin returns the value of Min.
+func (s *LimitedValue) GetMin() float64 {
+	return s.Min
+}
+
+// GetMax returns the value of Max.
+func (s *LimitedValue) GetMax() float64 {
+	return s.Max
+}
+
+// SetValue sets the value of Value.
+func (s *LimitedValue) SetValue(val float64) {
+	s.Value = val
+}
+
+// SetMin sets the value of Min.
+func (s *LimitedValue) SetMin(val float64) {
+	s.Min = val
+}
 
-func (*GetPetByIdNotFound) getPetByIdRes() {}
+// SetMax sets the value of Max.
+func (s *LimitedValue) SetMax(val float64) {
+	s.Max = val
+}
 
-// NewOptInt64 returns new OptInt64 with value set to v.
-func NewOptInt64(v int64) OptInt64 {
-	return OptInt64{
+// NewOptDateTime returns new OptDateTime with value set to v.
+func NewOptDateTime(v time.Time) OptDateTime {
+	return OptDateTime{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptInt64 is optional int64.
-type OptInt64 struct {
-	Value int64
+// OptDateTime is optional time.Time.
+type OptDateTime struct {
+	Value time.Time
 	Set   bool
 }
 
-// IsSet returns true if OptInt64 was set.
-func (o OptInt64) IsSet() bool { return o.Set }
+// IsSet returns true if OptDateTime was set.
+func (o OptDateTime) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptInt64) Reset() {
-	var v int64
+func (o *OptDateTime) Reset() {
+	var v time.Time
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptInt64) SetTo(v int64) {
+func (o *OptDateTime) SetTo(v time.Time) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptInt64) Get() (v int64, ok bool) {
+func (o OptDateTime) Get() (v time.Time, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -50,45 +632,45 @@ func (o OptInt64) Get() (v int64, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptInt64) Or(d int64) int64 {
+func (o OptDateTime) Or(d time.Time) time.Time {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// NewOptPetStatus returns new OptPetStatus with value set to v.
-func NewOptPetStatus(v PetStatus) OptPetStatus {
-	return OptPetStatus{
+// NewOptFloat64 returns new OptFloat64 with value set to v.
+func NewOptFloat64(v float64) OptFloat64 {
+	return OptFloat64{
 		Value: v,
 		Set:   true,
 	}
 }
 
-// OptPetStatus is optional PetStatus.
-type OptPetStatus struct {
-	Value PetStatus
+// OptFloat64 is optional float64.
+type OptFloat64 struct {
+	Value float64
 	Set   bool
 }
 
-// IsSet returns true if OptPetStatus was set.
-func (o OptPetStatus) IsSet() bool { return o.Set }
+// IsSet returns true if OptFloat64 was set.
+func (o OptFloat64) IsSet() bool { return o.Set }
 
 // Reset unsets value.
-func (o *OptPetStatus) Reset() {
-	var v PetStatus
+func (o *OptFloat64) Reset() {
+	var v float64
 	o.Value = v
 	o.Set = false
 }
 
 // SetTo sets value to v.
-func (o *OptPetStatus) SetTo(v PetStatus) {
+func (o *OptFloat64) SetTo(v float64) {
 	o.Set = true
 	o.Value = v
 }
 
 // Get returns value and boolean that denotes whether value was set.
-func (o OptPetStatus) Get() (v PetStatus, ok bool) {
+func (o OptFloat64) Get() (v float64, ok bool) {
 	if !o.Set {
 		return v, false
 	}
@@ -96,81 +678,889 @@ func (o OptPetStatus) Get() (v PetStatus, ok bool) {
 }
 
 // Or returns value if set, or given parameter if does not.
-func (o OptPetStatus) Or(d PetStatus) PetStatus {
+func (o OptFloat64) Or(d float64) float64 {
 	if v, ok := o.Get(); ok {
 		return v
 	}
 	return d
 }
 
-// Ref: #/components/schemas/Pet
-type Pet struct {
-	ID        OptInt64     `json:"id"`
-	Name      string       `json:"name"`
-	PhotoUrls []string     `json:"photoUrls"`
-	Status    OptPetStatus `json:"status"`
-}
-
-// GetID returns the value of ID.
-func (s *Pet) GetID() OptInt64 {
-	return s.ID
+// NewOptInt32 returns new OptInt32 with value set to v.
+func NewOptInt32(v int32) OptInt32 {
+	return OptInt32{
+		Value: v,
+		Set:   true,
+	}
 }
 
-// GetName returns the value of Name.
-func (s *Pet) GetName() string {
-	return s.Name
+// OptInt32 is optional int32.
+type OptInt32 struct {
+	Value int32
+	Set   bool
 }
 
-// GetPhotoUrls returns the value of PhotoUrls.
-func (s *Pet) GetPhotoUrls() []string {
-	return s.PhotoUrls
-}
+// IsSet returns true if OptInt32 was set.
+func (o OptInt32) IsSet() bool { return o.Set }
 
-// GetStatus returns the value of Status.
-func (s *Pet) GetStatus() OptPetStatus {
-	return s.Status
+// Reset unsets value.
+func (o *OptInt32) Reset() {
+	var v int32
+	o.Value = v
+	o.Set = false
 }
 
-// SetID sets the value of ID.
-func (s *Pet) SetID(val OptInt64) {
-	s.ID = val
+// SetTo sets value to v.
+func (o *OptInt32) SetTo(v int32) {
+	o.Set = true
+	o.Value = v
 }
 
-// SetName sets the value of Name.
-func (s *Pet) SetName(val string) {
-	s.Name = val
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt32) Get() (v int32, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
 }
 
-// SetPhotoUrls sets the value of PhotoUrls.
-func (s *Pet) SetPhotoUrls(val []string) {
-	s.PhotoUrls = val
+// Or returns value if set, or given parameter if does not.
+func (o OptInt32) Or(d int32) int32 {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
 }
 
-// SetStatus sets the value of Status.
-func (s *Pet) SetStatus(val OptPetStatus) {
-	s.Status = val
+// NewOptSnapshotFormat returns new OptSnapshotFormat with value set to v.
+func NewOptSnapshotFormat(v SnapshotFormat) OptSnapshotFormat {
+	return OptSnapshotFormat{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptSnapshotFormat is optional SnapshotFormat.
+type OptSnapshotFormat struct {
+	Value SnapshotFormat
+	Set   bool
+}
+
+// IsSet returns true if OptSnapshotFormat was set.
+func (o OptSnapshotFormat) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptSnapshotFormat) Reset() {
+	var v SnapshotFormat
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptSnapshotFormat) SetTo(v SnapshotFormat) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptSnapshotFormat) Get() (v SnapshotFormat, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptSnapshotFormat) Or(d SnapshotFormat) SnapshotFormat {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptString returns new OptString with value set to v.
+func NewOptString(v string) OptString {
+	return OptString{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptString is optional string.
+type OptString struct {
+	Value string
+	Set   bool
+}
+
+// IsSet returns true if OptString was set.
+func (o OptString) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptString) Reset() {
+	var v string
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptString) SetTo(v string) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptString) Get() (v string, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptString) Or(d string) string {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Publication the frames are fanned out to and its counters.
+// Ref: #/components/schemas/OutputStats
+type OutputStats struct {
+	Name string `json:"name"`
+	// Aeron channel URI.
+	Channel  string `json:"channel"`
+	StreamId int32  `json:"streamId"`
+	// Publishes the frames whose sequence number is a multiple of it.
+	Decimation int32 `json:"decimation"`
+	// Longest frame message in bytes the publication accepts, an eighth of its term length, or 0 if
+	// unknown.
+	MaxMessageLength int32            `json:"maxMessageLength"`
+	State            PublicationState `json:"state"`
+	// Frames accepted by the publication.
+	FramesPublished int64 `json:"framesPublished"`
+	// Frames discarded by the back-pressure policy.
+	FramesAbandoned int64 `json:"framesAbandoned"`
+	// Frames due on the output but not published, other than for a lack of subscribers.
+	PublicationDropped int64 `json:"publicationDropped"`
+	// Frames not published because the publication had no subscribers.
+	NotConnected int64 `json:"notConnected"`
+	// Publications recreated after being closed or reaching their max position.
+	Recreated int64 `json:"recreated"`
+	// Publication Offer2 calls by result.
+	OfferResults OutputStatsOfferResults `json:"offerResults"`
+	Policy       PolicyStats             `json:"policy"`
+}
+
+// GetName returns the value of Name.
+func (s *OutputStats) GetName() string {
+	return s.Name
+}
+
+// GetChannel returns the value of Channel.
+func (s *OutputStats) GetChannel() string {
+	return s.Channel
+}
+
+// GetStreamId returns the value of StreamId.
+func (s *OutputStats) GetStreamId() int32 {
+	return s.StreamId
+}
+
+// GetDecimation returns the value of Decimation.
+func (s *OutputStats) GetDecimation() int32 {
+	return s.Decimation
+}
+
+// GetMaxMessageLength returns the value of MaxMessageLength.
+func (s *OutputStats) GetMaxMessageLength() int32 {
+	return s.MaxMessageLength
+}
+
+// GetState returns the value of State.
+func (s *OutputStats) GetState() PublicationState {
+	return s.State
+}
+
+// GetFramesPublished returns the value of FramesPublished.
+func (s *OutputStats) GetFramesPublished() int64 {
+	return s.FramesPublished
+}
+
+// GetFramesAbandoned returns the value of FramesAbandoned.
+func (s *OutputStats) GetFramesAbandoned() int64 {
+	return s.FramesAbandoned
+}
+
+// GetPublicationDropped returns the value of PublicationDropped.
+func (s *OutputStats) GetPublicationDropped() int64 {
+	return s.PublicationDropped
+}
+
+// GetNotConnected returns the value of NotConnected.
+func (s *OutputStats) GetNotConnected() int64 {
+	return s.NotConnected
+}
+
+// GetRecreated returns the value of Recreated.
+func (s *OutputStats) GetRecreated() int64 {
+	return s.Recreated
+}
+
+// GetOfferResults returns the value of OfferResults.
+func (s *OutputStats) GetOfferResults() OutputStatsOfferResults {
+	return s.OfferResults
+}
+
+// GetPolicy returns the value of Policy.
+func (s *OutputStats) GetPolicy() PolicyStats {
+	return s.Policy
+}
+
+// SetName sets the value of Name.
+func (s *OutputStats) SetName(val string) {
+	s.Name = val
+}
+
+// SetChannel sets the value of Channel.
+func (s *OutputStats) SetChannel(val string) {
+	s.Channel = val
+}
+
+// SetStreamId sets the value of StreamId.
+func (s *OutputStats) SetStreamId(val int32) {
+	s.StreamId = val
+}
+
+// SetDecimation sets the value of Decimation.
+func (s *OutputStats) SetDecimation(val int32) {
+	s.Decimation = val
+}
+
+// SetMaxMessageLength sets the value of MaxMessageLength.
+func (s *OutputStats) SetMaxMessageLength(val int32) {
+	s.MaxMessageLength = val
+}
+
+// SetState sets the value of State.
+func (s *OutputStats) SetState(val PublicationState) {
+	s.State = val
+}
+
+// SetFramesPublished sets the value of FramesPublished.
+func (s *OutputStats) SetFramesPublished(val int64) {
+	s.FramesPublished = val
+}
+
+// SetFramesAbandoned sets the value of FramesAbandoned.
+func (s *OutputStats) SetFramesAbandoned(val int64) {
+	s.FramesAbandoned = val
+}
+
+// SetPublicationDropped sets the value of PublicationDropped.
+func (s *OutputStats) SetPublicationDropped(val int64) {
+	s.PublicationDropped = val
+}
+
+// SetNotConnected sets the value of NotConnected.
+func (s *OutputStats) SetNotConnected(val int64) {
+	s.NotConnected = val
+}
+
+// SetRecreated sets the value of Recreated.
+func (s *OutputStats) SetRecreated(val int64) {
+	s.Recreated = val
+}
+
+// SetOfferResults sets the value of OfferResults.
+func (s *OutputStats) SetOfferResults(val OutputStatsOfferResults) {
+	s.OfferResults = val
+}
+
+// SetPolicy sets the value of Policy.
+func (s *OutputStats) SetPolicy(val PolicyStats) {
+	s.Policy = val
+}
+
+// Publication Offer2 calls by result.
+type OutputStatsOfferResults map[string]int64
+
+func (s *OutputStatsOfferResults) init() OutputStatsOfferResults {
+	m := *s
+	if m == nil {
+		m = map[string]int64{}
+		*s = m
+	}
+	return m
+}
+
+// Ref: #/components/schemas/PipelineStats
+type PipelineStats struct {
+	// Frames received from the camera.
+	FramesReceived int64 `json:"framesReceived"`
+	// Frames missing from the camera image counter.
+	GrabberDropped int64 `json:"grabberDropped"`
+	// Frames dropped because the publisher queue was full.
+	QueueOverruns int64 `json:"queueOverruns"`
+	// Frames waiting in the publisher queue.
+	QueueDepth int32 `json:"queueDepth"`
+	// Frames the publisher queue holds.
+	QueueCapacity int32 `json:"queueCapacity"`
+	// Sequence number of the latest frame.
+	SequenceNumber int64 `json:"sequenceNumber"`
+	// Measured frame rate in Hz.
+	FrameRate float64       `json:"frameRate"`
+	Outputs   []OutputStats `json:"outputs"`
+}
+
+// GetFramesReceived returns the value of FramesReceived.
+func (s *PipelineStats) GetFramesReceived() int64 {
+	return s.FramesReceived
+}
+
+// GetGrabberDropped returns the value of GrabberDropped.
+func (s *PipelineStats) GetGrabberDropped() int64 {
+	return s.GrabberDropped
+}
+
+// GetQueueOverruns returns the value of QueueOverruns.
+func (s *PipelineStats) GetQueueOverruns() int64 {
+	return s.QueueOverruns
+}
+
+// GetQueueDepth returns the value of QueueDepth.
+func (s *PipelineStats) GetQueueDepth() int32 {
+	return s.QueueDepth
+}
+
+// GetQueueCapacity returns the value of QueueCapacity.
+func (s *PipelineStats) GetQueueCapacity() int32 {
+	return s.QueueCapacity
+}
+
+// GetSequenceNumber returns the value of SequenceNumber.
+func (s *PipelineStats) GetSequenceNumber() int64 {
+	return s.SequenceNumber
+}
+
+// GetFrameRate returns the value of FrameRate.
+func (s *PipelineStats) GetFrameRate() float64 {
+	return s.FrameRate
+}
+
+// GetOutputs returns the value of Outputs.
+func (s *PipelineStats) GetOutputs() []OutputStats {
+	return s.Outputs
+}
+
+// SetFramesReceived sets the value of FramesReceived.
+func (s *PipelineStats) SetFramesReceived(val int64) {
+	s.FramesReceived = val
+}
+
+// SetGrabberDropped sets the value of GrabberDropped.
+func (s *PipelineStats) SetGrabberDropped(val int64) {
+	s.GrabberDropped = val
+}
+
+// SetQueueOverruns sets the value of QueueOverruns.
+func (s *PipelineStats) SetQueueOverruns(val int64) {
+	s.QueueOverruns = val
+}
+
+// SetQueueDepth sets the value of QueueDepth.
+func (s *PipelineStats) SetQueueDepth(val int32) {
+	s.QueueDepth = val
+}
+
+// SetQueueCapacity sets the value of QueueCapacity.
+func (s *PipelineStats) SetQueueCapacity(val int32) {
+	s.QueueCapacity = val
+}
+
+// SetSequenceNumber sets the value of SequenceNumber.
+func (s *PipelineStats) SetSequenceNumber(val int64) {
+	s.SequenceNumber = val
+}
+
+// SetFrameRate sets the value of FrameRate.
+func (s *PipelineStats) SetFrameRate(val float64) {
+	s.FrameRate = val
+}
+
+// SetOutputs sets the value of Outputs.
+func (s *PipelineStats) SetOutputs(val []OutputStats) {
+	s.Outputs = val
+}
+
+// Back-pressure policy and the counters kept by each policy; only the counters of the policy in use
+// change.
+// Ref: #/components/schemas/PolicyStats
+type PolicyStats struct {
+	Policy BackPressurePolicy `json:"policy"`
+	// Retry timeout of the spin policy in seconds.
+	Timeout float64 `json:"timeout"`
+	// Frames discarded by the drop-newest policy.
+	DropNewestDropped int64 `json:"dropNewestDropped"`
+	// Offers retried by the spin policy.
+	SpinRetries int64 `json:"spinRetries"`
+	// Frames discarded by the spin policy after the timeout.
+	SpinTimeouts int64 `json:"spinTimeouts"`
+	// Offers retried by the block policy.
+	BlockRetries int64 `json:"blockRetries"`
+	// Total time frames waited to be accepted under the block policy.
+	BlockedSeconds float64 `json:"blockedSeconds"`
+	// Longest time a frame waited to be accepted under the block policy.
+	MaxBlockedSeconds float64 `json:"maxBlockedSeconds"`
+}
+
+// GetPolicy returns the value of Policy.
+func (s *PolicyStats) GetPolicy() BackPressurePolicy {
+	return s.Policy
+}
+
+// GetTimeout returns the value of Timeout.
+func (s *PolicyStats) GetTimeout() float64 {
+	return s.Timeout
+}
+
+// GetDropNewestDropped returns the value of DropNewestDropped.
+func (s *PolicyStats) GetDropNewestDropped() int64 {
+	return s.DropNewestDropped
+}
+
+// GetSpinRetries returns the value of SpinRetries.
+func (s *PolicyStats) GetSpinRetries() int64 {
+	return s.SpinRetries
+}
+
+// GetSpinTimeouts returns the value of SpinTimeouts.
+func (s *PolicyStats) GetSpinTimeouts() int64 {
+	return s.SpinTimeouts
+}
+
+// GetBlockRetries returns the value of BlockRetries.
+func (s *PolicyStats) GetBlockRetries() int64 {
+	return s.BlockRetries
+}
+
+// GetBlockedSeconds returns the value of BlockedSeconds.
+func (s *PolicyStats) GetBlockedSeconds() float64 {
+	return s.BlockedSeconds
+}
+
+// GetMaxBlockedSeconds returns the value of MaxBlockedSeconds.
+func (s *PolicyStats) GetMaxBlockedSeconds() float64 {
+	return s.MaxBlockedSeconds
+}
+
+// SetPolicy sets the value of Policy.
+func (s *PolicyStats) SetPolicy(val BackPressurePolicy) {
+	s.Policy = val
+}
+
+// SetTimeout sets the value of Timeout.
+func (s *PolicyStats) SetTimeout(val float64) {
+	s.Timeout = val
+}
+
+// SetDropNewestDropped sets the value of DropNewestDropped.
+func (s *PolicyStats) SetDropNewestDropped(val int64) {
+	s.DropNewestDropped = val
+}
+
+// SetSpinRetries sets the value of SpinRetries.
+func (s *PolicyStats) SetSpinRetries(val int64) {
+	s.SpinRetries = val
+}
+
+// SetSpinTimeouts sets the value of SpinTimeouts.
+func (s *PolicyStats) SetSpinTimeouts(val int64) {
+	s.SpinTimeouts = val
+}
+
+// SetBlockRetries sets the value of BlockRetries.
+func (s *PolicyStats) SetBlockRetries(val int64) {
+	s.BlockRetries = val
+}
+
+// SetBlockedSeconds sets the value of BlockedSeconds.
+func (s *PolicyStats) SetBlockedSeconds(val float64) {
+	s.BlockedSeconds = val
+}
+
+// SetMaxBlockedSeconds sets the value of MaxBlockedSeconds.
+func (s *PolicyStats) SetMaxBlockedSeconds(val float64) {
+	s.MaxBlockedSeconds = val
+}
+
+// Ref: #/components/schemas/PublicationState
+type PublicationState string
+
+const (
+	PublicationStateConnected           PublicationState = "connected"
+	PublicationStateNotConnected        PublicationState = "not-connected"
+	PublicationStateClosed              PublicationState = "closed"
+	PublicationStateMaxPositionExceeded PublicationState = "max-position-exceeded"
+	PublicationStateRecreating          PublicationState = "recreating"
+	PublicationStateDisconnected        PublicationState = "disconnected"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s PublicationState) MarshalText() ([]byte, error) {
+	switch s {
+	case PublicationStateConnected:
+		return []byte(s), nil
+	case PublicationStateNotConnected:
+		return []byte(s), nil
+	case PublicationStateClosed:
+		return []byte(s), nil
+	case PublicationStateMaxPositionExceeded:
+		return []byte(s), nil
+	case PublicationStateRecreating:
+		return []byte(s), nil
+	case PublicationStateDisconnected:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *PublicationState) UnmarshalText(data []byte) error {
+	switch PublicationState(data) {
+	case PublicationStateConnected:
+		*s = PublicationStateConnected
+		return nil
+	case PublicationStateNotConnected:
+		*s = PublicationStateNotConnected
+		return nil
+	case PublicationStateClosed:
+		*s = PublicationStateClosed
+		return nil
+	case PublicationStateMaxPositionExceeded:
+		*s = PublicationStateMaxPositionExceeded
+		return nil
+	case PublicationStateRecreating:
+		*s = PublicationStateRecreating
+		return nil
+	case PublicationStateDisconnected:
+		*s = PublicationStateDisconnected
+		return nil
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/ROI
+type ROI struct {
+	Width   int32 `json:"width"`
+	Height  int32 `json:"height"`
+	OffsetX int32 `json:"offsetX"`
+	OffsetY int32 `json:"offsetY"`
+}
+
+// GetWidth returns the value of Width.
+func (s *ROI) GetWidth() int32 {
+	return s.Width
+}
+
+// GetHeight returns the value of Height.
+func (s *ROI) GetHeight() int32 {
+	return s.Height
+}
+
+// GetOffsetX returns the value of OffsetX.
+func (s *ROI) GetOffsetX() int32 {
+	return s.OffsetX
+}
+
+// GetOffsetY returns the value of OffsetY.
+func (s *ROI) GetOffsetY() int32 {
+	return s.OffsetY
+}
+
+// SetWidth sets the value of Width.
+func (s *ROI) SetWidth(val int32) {
+	s.Width = val
+}
+
+// SetHeight sets the value of Height.
+func (s *ROI) SetHeight(val int32) {
+	s.Height = val
+}
+
+// SetOffsetX sets the value of OffsetX.
+func (s *ROI) SetOffsetX(val int32) {
+	s.OffsetX = val
+}
+
+// SetOffsetY sets the value of OffsetY.
+func (s *ROI) SetOffsetY(val int32) {
+	s.OffsetY = val
+}
+
+// Ref: #/components/schemas/Recording
+type Recording struct {
+	ID int64 `json:"id"`
+	// Aeron channel URI of the recorded publication.
+	Channel   string `json:"channel"`
+	StreamId  int32  `json:"streamId"`
+	SessionId int32  `json:"sessionId"`
+	// The archive is recording the stream.
+	Active    bool      `json:"active"`
+	StartTime time.Time `json:"startTime"`
+	// Unset while the recording is active.
+	StopTime      OptDateTime `json:"stopTime"`
+	StartPosition int64       `json:"startPosition"`
+	// Current position while the recording is active.
+	StopPosition int64 `json:"stopPosition"`
+	// Frames recorded, estimated from the recorded bytes and the current frame size.
+	Frames int64 `json:"frames"`
+}
+
+// GetID returns the value of ID.
+func (s *Recording) GetID() int64 {
+	return s.ID
+}
+
+// GetChannel returns the value of Channel.
+func (s *Recording) GetChannel() string {
+	return s.Channel
+}
+
+// GetStreamId returns the value of StreamId.
+func (s *Recording) GetStreamId() int32 {
+	return s.StreamId
+}
+
+// GetSessionId returns the value of SessionId.
+func (s *Recording) GetSessionId() int32 {
+	return s.SessionId
+}
+
+// GetActive returns the value of Active.
+func (s *Recording) GetActive() bool {
+	return s.Active
+}
+
+// GetStartTime returns the value of StartTime.
+func (s *Recording) GetStartTime() time.Time {
+	return s.StartTime
+}
+
+// GetStopTime returns the value of StopTime.
+func (s *Recording) GetStopTime() OptDateTime {
+	return s.StopTime
+}
+
+// GetStartPosition returns the value of StartPosition.
+func (s *Recording) GetStartPosition() int64 {
+	return s.StartPosition
+}
+
+// GetStopPosition returns the value of StopPosition.
+func (s *Recording) GetStopPosition() int64 {
+	return s.StopPosition
+}
+
+// GetFrames returns the value of Frames.
+func (s *Recording) GetFrames() int64 {
+	return s.Frames
+}
+
+// SetID sets the value of ID.
+func (s *Recording) SetID(val int64) {
+	s.ID = val
+}
+
+// SetChannel sets the value of Channel.
+func (s *Recording) SetChannel(val string) {
+	s.Channel = val
+}
+
+// SetStreamId sets the value of StreamId.
+func (s *Recording) SetStreamId(val int32) {
+	s.StreamId = val
+}
+
+// SetSessionId sets the value of SessionId.
+func (s *Recording) SetSessionId(val int32) {
+	s.SessionId = val
+}
+
+// SetActive sets the value of Active.
+func (s *Recording) SetActive(val bool) {
+	s.Active = val
+}
+
+// SetStartTime sets the value of StartTime.
+func (s *Recording) SetStartTime(val time.Time) {
+	s.StartTime = val
+}
+
+// SetStopTime sets the value of StopTime.
+func (s *Recording) SetStopTime(val OptDateTime) {
+	s.StopTime = val
+}
+
+// SetStartPosition sets the value of StartPosition.
+func (s *Recording) SetStartPosition(val int64) {
+	s.StartPosition = val
+}
+
+// SetStopPosition sets the value of StopPosition.
+func (s *Recording) SetStopPosition(val int64) {
+	s.StopPosition = val
+}
+
+// SetFrames sets the value of Frames.
+func (s *Recording) SetFrames(val int64) {
+	s.Frames = val
+}
+
+// Ref: #/components/schemas/Replay
+type Replay struct {
+	// Replay session id.
+	ID          int64   `json:"id"`
+	RecordingId int64   `json:"recordingId"`
+	Channel     string  `json:"channel"`
+	StreamId    int32   `json:"streamId"`
+	Rate        float64 `json:"rate"`
+	// Frames republished.
+	Frames int64 `json:"frames"`
+}
+
+// GetID returns the value of ID.
+func (s *Replay) GetID() int64 {
+	return s.ID
+}
+
+// GetRecordingId returns the value of RecordingId.
+func (s *Replay) GetRecordingId() int64 {
+	return s.RecordingId
+}
+
+// GetChannel returns the value of Channel.
+func (s *Replay) GetChannel() string {
+	return s.Channel
+}
+
+// GetStreamId returns the value of StreamId.
+func (s *Replay) GetStreamId() int32 {
+	return s.StreamId
+}
+
+// GetRate returns the value of Rate.
+func (s *Replay) GetRate() float64 {
+	return s.Rate
+}
+
+// GetFrames returns the value of Frames.
+func (s *Replay) GetFrames() int64 {
+	return s.Frames
+}
+
+// SetID sets the value of ID.
+func (s *Replay) SetID(val int64) {
+	s.ID = val
+}
+
+// SetRecordingId sets the value of RecordingId.
+func (s *Replay) SetRecordingId(val int64) {
+	s.RecordingId = val
+}
+
+// SetChannel sets the value of Channel.
+func (s *Replay) SetChannel(val string) {
+	s.Channel = val
+}
+
+// SetStreamId sets the value of StreamId.
+func (s *Replay) SetStreamId(val int32) {
+	s.StreamId = val
+}
+
+// SetRate sets the value of Rate.
+func (s *Replay) SetRate(val float64) {
+	s.Rate = val
+}
+
+// SetFrames sets the value of Frames.
+func (s *Replay) SetFrames(val int64) {
+	s.Frames = val
+}
+
+// Ref: #/components/schemas/ReplayRequest
+type ReplayRequest struct {
+	// Aeron channel URI the frames are republished on.
+	Channel  string `json:"channel"`
+	StreamId int32  `json:"streamId"`
+	// Replay speed relative to the recorded frame rate, 0 replays as fast as possible.
+	Rate OptFloat64 `json:"rate"`
+}
+
+// GetChannel returns the value of Channel.
+func (s *ReplayRequest) GetChannel() string {
+	return s.Channel
+}
+
+// GetStreamId returns the value of StreamId.
+func (s *ReplayRequest) GetStreamId() int32 {
+	return s.StreamId
+}
+
+// GetRate returns the value of Rate.
+func (s *ReplayRequest) GetRate() OptFloat64 {
+	return s.Rate
 }
 
-func (*Pet) getPetByIdRes() {}
+// SetChannel sets the value of Channel.
+func (s *ReplayRequest) SetChannel(val string) {
+	s.Channel = val
+}
+
+// SetStreamId sets the value of StreamId.
+func (s *ReplayRequest) SetStreamId(val int32) {
+	s.StreamId = val
+}
 
-// Pet status in the store.
-// Ref: #/components/schemas/PetStatus
-type PetStatus string
+// SetRate sets the value of Rate.
+func (s *ReplayRequest) SetRate(val OptFloat64) {
+	s.Rate = val
+}
+
+// Ref: #/components/schemas/SetValue
+type SetValue struct {
+	Value float64 `json:"value"`
+}
+
+// GetValue returns the value of Value.
+func (s *SetValue) GetValue() float64 {
+	return s.Value
+}
+
+// SetValue sets the value of Value.
+func (s *SetValue) SetValue(val float64) {
+	s.Value = val
+}
+
+// Ref: #/components/schemas/SnapshotFormat
+type SnapshotFormat string
 
 const (
-	PetStatusAvailable PetStatus = "available"
-	PetStatusPending   PetStatus = "pending"
-	PetStatusSold      PetStatus = "sold"
+	SnapshotFormatFits SnapshotFormat = "fits"
+	SnapshotFormatPNG  SnapshotFormat = "png"
+	SnapshotFormatRaw  SnapshotFormat = "raw"
 )
 
 // MarshalText implements encoding.TextMarshaler.
-func (s PetStatus) MarshalText() ([]byte, error) {
+func (s SnapshotFormat) MarshalText() ([]byte, error) {
 	switch s {
-	case PetStatusAvailable:
+	case SnapshotFormatFits:
 		return []byte(s), nil
-	case PetStatusPending:
+	case SnapshotFormatPNG:
 		return []byte(s), nil
-	case PetStatusSold:
+	case SnapshotFormatRaw:
 		return []byte(s), nil
 	default:
 		return nil, errors.Errorf("invalid value: %q", s)
@@ -178,16 +1568,213 @@ func (s PetStatus) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
-func (s *PetStatus) UnmarshalText(data []byte) error {
-	switch PetStatus(data) {
-	case PetStatusAvailable:
-		*s = PetStatusAvailable
+func (s *SnapshotFormat) UnmarshalText(data []byte) error {
+	switch SnapshotFormat(data) {
+	case SnapshotFormatFits:
+		*s = SnapshotFormatFits
+		return nil
+	case SnapshotFormatPNG:
+		*s = SnapshotFormatPNG
+		return nil
+	case SnapshotFormatRaw:
+		*s = SnapshotFormatRaw
 		return nil
-	case PetStatusPending:
-		*s = PetStatusPending
+	default:
+		return errors.Errorf("invalid value: %q", data)
+	}
+}
+
+// Ref: #/components/schemas/StartRecording
+type StartRecording struct {
+	// Name of the output to record, the first output if unset.
+	Output OptString `json:"output"`
+}
+
+// GetOutput returns the value of Output.
+func (s *StartRecording) GetOutput() OptString {
+	return s.Output
+}
+
+// SetOutput sets the value of Output.
+func (s *StartRecording) SetOutput(val OptString) {
+	s.Output = val
+}
+
+// StopReplayNoContent is response for StopReplay operation.
+type StopReplayNoContent struct{}
+
+// Temperatures in degrees Celsius.
+// Ref: #/components/schemas/ThermalStatus
+type ThermalStatus struct {
+	Sensor      float64 `json:"sensor"`
+	Motherboard float64 `json:"motherboard"`
+	Peltier     float64 `json:"peltier"`
+	Heatsink    float64 `json:"heatsink"`
+	// Thermoelectric cooler power in percent.
+	TecPower float64 `json:"tecPower"`
+	Setpoint float64 `json:"setpoint"`
+	// Sensor has settled at the setpoint.
+	Stable bool      `json:"stable"`
+	Time   time.Time `json:"time"`
+}
+
+// GetSensor returns the value of Sensor.
+func (s *ThermalStatus) GetSensor() float64 {
+	return s.Sensor
+}
+
+// GetMotherboard returns the value of Motherboard.
+func (s *ThermalStatus) GetMotherboard() float64 {
+	return s.Motherboard
+}
+
+// GetPeltier returns the value of Peltier.
+func (s *ThermalStatus) GetPeltier() float64 {
+	return s.Peltier
+}
+
+// GetHeatsink returns the value of Heatsink.
+func (s *ThermalStatus) GetHeatsink() float64 {
+	return s.Heatsink
+}
+
+// GetTecPower returns the value of TecPower.
+func (s *ThermalStatus) GetTecPower() float64 {
+	return s.TecPower
+}
+
+// GetSetpoint returns the value of Setpoint.
+func (s *ThermalStatus) GetSetpoint() float64 {
+	return s.Setpoint
+}
+
+// GetStable returns the value of Stable.
+func (s *ThermalStatus) GetStable() bool {
+	return s.Stable
+}
+
+// GetTime returns the value of Time.
+func (s *ThermalStatus) GetTime() time.Time {
+	return s.Time
+}
+
+// SetSensor sets the value of Sensor.
+func (s *ThermalStatus) SetSensor(val float64) {
+	s.Sensor = val
+}
+
+// SetMotherboard sets the value of Motherboard.
+func (s *ThermalStatus) SetMotherboard(val float64) {
+	s.Motherboard = val
+}
+
+// SetPeltier sets the value of Peltier.
+func (s *ThermalStatus) SetPeltier(val float64) {
+	s.Peltier = val
+}
+
+// SetHeatsink sets the value of Heatsink.
+func (s *ThermalStatus) SetHeatsink(val float64) {
+	s.Heatsink = val
+}
+
+// SetTecPower sets the value of TecPower.
+func (s *ThermalStatus) SetTecPower(val float64) {
+	s.TecPower = val
+}
+
+// SetSetpoint sets the value of Setpoint.
+func (s *ThermalStatus) SetSetpoint(val float64) {
+	s.Setpoint = val
+}
+
+// SetStable sets the value of Stable.
+func (s *ThermalStatus) SetStable(val bool) {
+	s.Stable = val
+}
+
+// SetTime sets the value of Time.
+func (s *ThermalStatus) SetTime(val time.Time) {
+	s.Time = val
+}
+
+// Ref: #/components/schemas/TransportStatus
+type TransportStatus struct {
+	State TransportStatusState `json:"state"`
+	// Time the state was entered.
+	Since time.Time `json:"since"`
+	// Error the connection was last lost with.
+	LastError  OptString `json:"lastError"`
+	Reconnects int64     `json:"reconnects"`
+}
+
+// GetState returns the value of State.
+func (s *TransportStatus) GetState() TransportStatusState {
+	return s.State
+}
+
+// GetSince returns the value of Since.
+func (s *TransportStatus) GetSince() time.Time {
+	return s.Since
+}
+
+// GetLastError returns the value of LastError.
+func (s *TransportStatus) GetLastError() OptString {
+	return s.LastError
+}
+
+// GetReconnects returns the value of Reconnects.
+func (s *TransportStatus) GetReconnects() int64 {
+	return s.Reconnects
+}
+
+// SetState sets the value of State.
+func (s *TransportStatus) SetState(val TransportStatusState) {
+	s.State = val
+}
+
+// SetSince sets the value of Since.
+func (s *TransportStatus) SetSince(val time.Time) {
+	s.Since = val
+}
+
+// SetLastError sets the value of LastError.
+func (s *TransportStatus) SetLastError(val OptString) {
+	s.LastError = val
+}
+
+// SetReconnects sets the value of Reconnects.
+func (s *TransportStatus) SetReconnects(val int64) {
+	s.Reconnects = val
+}
+
+type TransportStatusState string
+
+const (
+	TransportStatusStateConnected    TransportStatusState = "connected"
+	TransportStatusStateReconnecting TransportStatusState = "reconnecting"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TransportStatusState) MarshalText() ([]byte, error) {
+	switch s {
+	case TransportStatusStateConnected:
+		return []byte(s), nil
+	case TransportStatusStateReconnecting:
+		return []byte(s), nil
+	default:
+		return nil, errors.Errorf("invalid value: %q", s)
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *TransportStatusState) UnmarshalText(data []byte) error {
+	switch TransportStatusState(data) {
+	case TransportStatusStateConnected:
+		*s = TransportStatusStateConnected
 		return nil
-	case PetStatusSold:
-		*s = PetStatusSold
+	case TransportStatusStateReconnecting:
+		*s = TransportStatusStateReconnecting
 		return nil
 	default:
 		return errors.Errorf("invalid value: %q", data)
@@ -8,12 +8,150 @@ import (
 
 // Handler handles operations described by OpenAPI v3 specification.
 type Handler interface {
-	// GetPetById implements getPetById operation.
+	// GetAcquisition implements getAcquisition operation.
 	//
-	// Returns a single pet.
+	// Get acquisition state.
 	//
-	// GET /pet/{petId}
-	GetPetById(ctx context.Context, params GetPetByIdParams) (GetPetByIdRes, error)
+	// GET /camera/acquisition
+	GetAcquisition(ctx context.Context) (*AcquisitionState, error)
+	// GetCamera implements getCamera operation.
+	//
+	// Get camera identity.
+	//
+	// GET /camera
+	GetCamera(ctx context.Context) (*CameraInfo, error)
+	// GetFrameRate implements getFrameRate operation.
+	//
+	// Get frame rate.
+	//
+	// GET /camera/frame-rate
+	GetFrameRate(ctx context.Context) (*LimitedValue, error)
+	// GetGeometry implements getGeometry operation.
+	//
+	// Get current image geometry.
+	//
+	// GET /camera/geometry
+	GetGeometry(ctx context.Context) (*Geometry, error)
+	// GetIntegrationTime implements getIntegrationTime operation.
+	//
+	// Get integration time.
+	//
+	// GET /camera/integration-time
+	GetIntegrationTime(ctx context.Context) (*LimitedValue, error)
+	// GetSensorSetpoint implements getSensorSetpoint operation.
+	//
+	// Get sensor cooling setpoint.
+	//
+	// GET /camera/temperature/setpoint
+	GetSensorSetpoint(ctx context.Context) (*LimitedValue, error)
+	// GetSnapshot implements getSnapshot operation.
+	//
+	// Waits for the next frames published and returns them, or their mean, as a FITS image with header
+	// keywords from the camera configuration, a 16-bit grayscale PNG or raw little-endian 16-bit pixels
+	// in row-major order. The geometry and sequence numbers are returned in headers for every format.
+	// The mean of several frames is rounded to integers, except in FITS where it is written as 32-bit
+	// floats. Fails if acquisition is stopped or paused.
+	//
+	// GET /camera/snapshot
+	GetSnapshot(ctx context.Context, params GetSnapshotParams) (GetSnapshotRes, error)
+	// GetStats implements getStats operation.
+	//
+	// Get frame pipeline statistics.
+	//
+	// GET /camera/stats
+	GetStats(ctx context.Context) (*PipelineStats, error)
+	// GetTemperature implements getTemperature operation.
+	//
+	// Get temperatures.
+	//
+	// GET /camera/temperature
+	GetTemperature(ctx context.Context) (*ThermalStatus, error)
+	// GetTransport implements getTransport operation.
+	//
+	// The service reconnects to the media driver with backoff when it is lost, dropping frames meanwhile.
+	//
+	// GET /aeron
+	GetTransport(ctx context.Context) (*TransportStatus, error)
+	// ListRecordings implements listRecordings operation.
+	//
+	// Lists the recordings of the archive catalog on the stream ids of the outputs.
+	//
+	// GET /archive/recordings
+	ListRecordings(ctx context.Context) ([]Recording, error)
+	// ListReplays implements listReplays operation.
+	//
+	// List the replays in progress.
+	//
+	// GET /archive/replays
+	ListReplays(ctx context.Context) ([]Replay, error)
+	// SetFrameRate implements setFrameRate operation.
+	//
+	// Returns the frame rate accepted by the camera.
+	//
+	// PUT /camera/frame-rate
+	SetFrameRate(ctx context.Context, req *SetValue) (*LimitedValue, error)
+	// SetIntegrationTime implements setIntegrationTime operation.
+	//
+	// Returns the integration time accepted by the camera.
+	//
+	// PUT /camera/integration-time
+	SetIntegrationTime(ctx context.Context, req *SetValue) (*LimitedValue, error)
+	// SetROI implements setROI operation.
+	//
+	// Stops acquisition, applies the sensor cropping window and restarts acquisition if it was running.
+	// Returns the resulting geometry. The window must lie within the sensor, whose coordinates are
+	// 16-bit.
+	//
+	// PUT /camera/geometry
+	SetROI(ctx context.Context, req *ROI) (*Geometry, error)
+	// SetSensorSetpoint implements setSensorSetpoint operation.
+	//
+	// Returns the setpoint accepted by the camera.
+	//
+	// PUT /camera/temperature/setpoint
+	SetSensorSetpoint(ctx context.Context, req *SetValue) (*LimitedValue, error)
+	// StartAcquisition implements startAcquisition operation.
+	//
+	// Start acquisition.
+	//
+	// POST /camera/acquisition/start
+	StartAcquisition(ctx context.Context) (*AcquisitionState, error)
+	// StartRecording implements startRecording operation.
+	//
+	// Start recording an output.
+	//
+	// POST /archive/recordings
+	StartRecording(ctx context.Context, req *StartRecording) (*Recording, error)
+	// StartReplay implements startReplay operation.
+	//
+	// Republishes the frames of the recording on a channel and stream, in the wire format of the live
+	// stream, at the recorded frame rate times the replay rate. Active recordings are replayed up to
+	// their current position.
+	//
+	// POST /archive/recordings/{recordingId}/replay
+	StartReplay(ctx context.Context, req *ReplayRequest, params StartReplayParams) (*Replay, error)
+	// StopAcquisition implements stopAcquisition operation.
+	//
+	// Stop acquisition.
+	//
+	// POST /camera/acquisition/stop
+	StopAcquisition(ctx context.Context) (*AcquisitionState, error)
+	// StopRecording implements stopRecording operation.
+	//
+	// Stop a recording.
+	//
+	// POST /archive/recordings/{recordingId}/stop
+	StopRecording(ctx context.Context, params StopRecordingParams) (*Recording, error)
+	// StopReplay implements stopReplay operation.
+	//
+	// Stop a replay.
+	//
+	// DELETE /archive/replays/{replayId}
+	StopReplay(ctx context.Context, params StopReplayParams) error
+	// NewError creates *ErrorStatusCode from error returned by handler.
+	//
+	// Used for common default response.
+	NewError(ctx context.Context, err error) *ErrorStatusCode
 }
 
 // Server implements http server based on OpenAPI v3 specification and
@@ -13,11 +13,216 @@ type UnimplementedHandler struct{}
 
 var _ Handler = UnimplementedHandler{}
 
-// GetPetById implements getPetById operation.
+// GetAcquisition implements getAcquisition operation.
 //
-// Returns a single pet.
+// Get acquisition state.
 //
-// GET /pet/{petId}
-func (UnimplementedHandler) GetPetById(ctx context.Context, params GetPetByIdParams) (r GetPetByIdRes, _ error) {
+// GET /camera/acquisition
+func (UnimplementedHandler) GetAcquisition(ctx context.Context) (r *AcquisitionState, _ error) {
 	return r, ht.ErrNotImplemented
 }
+
+// GetCamera implements getCamera operation.
+//
+// Get camera identity.
+//
+// GET /camera
+func (UnimplementedHandler) GetCamera(ctx context.Context) (r *CameraInfo, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetFrameRate implements getFrameRate operation.
+//
+// Get frame rate.
+//
+// GET /camera/frame-rate
+func (UnimplementedHandler) GetFrameRate(ctx context.Context) (r *LimitedValue, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetGeometry implements getGeometry operation.
+//
+// Get current image geometry.
+//
+// GET /camera/geometry
+func (UnimplementedHandler) GetGeometry(ctx context.Context) (r *Geometry, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetIntegrationTime implements getIntegrationTime operation.
+//
+// Get integration time.
+//
+// GET /camera/integration-time
+func (UnimplementedHandler) GetIntegrationTime(ctx context.Context) (r *LimitedValue, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetSensorSetpoint implements getSensorSetpoint operation.
+//
+// Get sensor cooling setpoint.
+//
+// GET /camera/temperature/setpoint
+func (UnimplementedHandler) GetSensorSetpoint(ctx context.Context) (r *LimitedValue, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetSnapshot implements getSnapshot operation.
+//
+// Waits for the next frames published and returns them, or their mean, as a FITS image with header
+// keywords from the camera configuration, a 16-bit grayscale PNG or raw little-endian 16-bit pixels
+// in row-major order. The geometry and sequence numbers are returned in headers for every format.
+// The mean of several frames is rounded to integers, except in FITS where it is written as 32-bit
+// floats. Fails if acquisition is stopped or paused.
+//
+// GET /camera/snapshot
+func (UnimplementedHandler) GetSnapshot(ctx context.Context, params GetSnapshotParams) (r GetSnapshotRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetStats implements getStats operation.
+//
+// Get frame pipeline statistics.
+//
+// GET /camera/stats
+func (UnimplementedHandler) GetStats(ctx context.Context) (r *PipelineStats, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetTemperature implements getTemperature operation.
+//
+// Get temperatures.
+//
+// GET /camera/temperature
+func (UnimplementedHandler) GetTemperature(ctx context.Context) (r *ThermalStatus, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// GetTransport implements getTransport operation.
+//
+// The service reconnects to the media driver with backoff when it is lost, dropping frames meanwhile.
+//
+// GET /aeron
+func (UnimplementedHandler) GetTransport(ctx context.Context) (r *TransportStatus, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListRecordings implements listRecordings operation.
+//
+// Lists the recordings of the archive catalog on the stream ids of the outputs.
+//
+// GET /archive/recordings
+func (UnimplementedHandler) ListRecordings(ctx context.Context) (r []Recording, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// ListReplays implements listReplays operation.
+//
+// List the replays in progress.
+//
+// GET /archive/replays
+func (UnimplementedHandler) ListReplays(ctx context.Context) (r []Replay, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetFrameRate implements setFrameRate operation.
+//
+// Returns the frame rate accepted by the camera.
+//
+// PUT /camera/frame-rate
+func (UnimplementedHandler) SetFrameRate(ctx context.Context, req *SetValue) (r *LimitedValue, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetIntegrationTime implements setIntegrationTime operation.
+//
+// Returns the integration time accepted by the camera.
+//
+// PUT /camera/integration-time
+func (UnimplementedHandler) SetIntegrationTime(ctx context.Context, req *SetValue) (r *LimitedValue, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetROI implements setROI operation.
+//
+// Stops acquisition, applies the sensor cropping window and restarts acquisition if it was running.
+// Returns the resulting geometry. The window must lie within the sensor, whose coordinates are
+// 16-bit.
+//
+// PUT /camera/geometry
+func (UnimplementedHandler) SetROI(ctx context.Context, req *ROI) (r *Geometry, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// SetSensorSetpoint implements setSensorSetpoint operation.
+//
+// Returns the setpoint accepted by the camera.
+//
+// PUT /camera/temperature/setpoint
+func (UnimplementedHandler) SetSensorSetpoint(ctx context.Context, req *SetValue) (r *LimitedValue, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StartAcquisition implements startAcquisition operation.
+//
+// Start acquisition.
+//
+// POST /camera/acquisition/start
+func (UnimplementedHandler) StartAcquisition(ctx context.Context) (r *AcquisitionState, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StartRecording implements startRecording operation.
+//
+// Start recording an output.
+//
+// POST /archive/recordings
+func (UnimplementedHandler) StartRecording(ctx context.Context, req *StartRecording) (r *Recording, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StartReplay implements startReplay operation.
+//
+// Republishes the frames of the recording on a channel and stream, in the wire format of the live
+// stream, at the recorded frame rate times the replay rate. Active recordings are replayed up to
+// their current position.
+//
+// POST /archive/recordings/{recordingId}/replay
+func (UnimplementedHandler) StartReplay(ctx context.Context, req *ReplayRequest, params StartReplayParams) (r *Replay, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StopAcquisition implements stopAcquisition operation.
+//
+// Stop acquisition.
+//
+// POST /camera/acquisition/stop
+func (UnimplementedHandler) StopAcquisition(ctx context.Context) (r *AcquisitionState, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StopRecording implements stopRecording operation.
+//
+// Stop a recording.
+//
+// POST /archive/recordings/{recordingId}/stop
+func (UnimplementedHandler) StopRecording(ctx context.Context, params StopRecordingParams) (r *Recording, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
+// StopReplay implements stopReplay operation.
+//
+// Stop a replay.
+//
+// DELETE /archive/replays/{replayId}
+func (UnimplementedHandler) StopReplay(ctx context.Context, params StopReplayParams) error {
+	return ht.ErrNotImplemented
+}
+
+// NewError creates *ErrorStatusCode from error returned by handler.
+//
+// Used for common default response.
+func (UnimplementedHandler) NewError(ctx context.Context, err error) (r *ErrorStatusCode) {
+	r = new(ErrorStatusCode)
+	return r
+}
@@ -0,0 +1,321 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+
+	"go.uber.org/zap"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+)
+
+// Pacing selects how fast recorded frames are replayed.
+type Pacing string
+
+const (
+	// PacingOriginal reproduces the recorded frame timing.
+	PacingOriginal Pacing = "original"
+	// PacingFixed replays at Config.FPS.
+	PacingFixed Pacing = "fps"
+	// PacingFast replays as fast as frames can be published.
+	PacingFast Pacing = "fast"
+)
+
+func ParsePacing(s string) (Pacing, error) {
+	switch p := Pacing(s); p {
+	case PacingOriginal, PacingFixed, PacingFast:
+		return p, nil
+	default:
+		return "", fmt.Errorf("replay: unknown pacing: %s", s)
+	}
+}
+
+type Config struct {
+	// Path is a FITS cube or a directory of frames.
+	Path   string
+	Pacing Pacing
+	FPS    float64
+	Loop   bool
+	// Width and Height are the size of raw frames, which do not record it.
+	Width  int
+	Height int
+}
+
+// Compile-time check for Backend.
+var (
+	_ app.CameraBackend  = (*Backend)(nil)
+	_ app.SensorReporter = (*Backend)(nil)
+)
+
+// Backend is an app.CameraBackend that replays recorded frames. The
+// recorded frames play the part of the sensor, which the region of interest
+// crops.
+type Backend struct {
+	lg      *zap.Logger
+	config  Config
+	handler app.FrameHandler
+	src     source
+	// pixels holds the recorded frame and window the cropped image handed
+	// to the handler, which is pixels itself if the window is the frame.
+	pixels []uint16
+	window []uint16
+	crop   app.Geometry
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+	err  error
+}
+
+func NewBackend(lg *zap.Logger, config Config) (*Backend, error) {
+	if _, err := ParsePacing(string(config.Pacing)); err != nil {
+		return nil, err
+	}
+	if config.Pacing == PacingFixed && config.FPS <= 0 {
+		return nil, fmt.Errorf("replay: invalid frame rate: %v", config.FPS)
+	}
+	return &Backend{lg: lg.Named("replay"), config: config}, nil
+}
+
+// Detect opens the recording and accepts any serial number.
+func (b *Backend) Detect(serialNumber string) (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.src != nil {
+		return fmt.Sprintf("Replay of %s", b.config.Path), nil
+	}
+
+	info, err := os.Stat(b.config.Path)
+	if err != nil {
+		return "", err
+	}
+	var src source
+	if info.IsDir() {
+		src, err = openDir(b.config.Path, b.config.Width, b.config.Height)
+	} else {
+		src, err = openFits(b.config.Path)
+	}
+	if err != nil {
+		return "", err
+	}
+	if b.config.Pacing == PacingOriginal {
+		if err := checkTiming(src, b.config.Loop); err != nil {
+			src.Close()
+			return "", fmt.Errorf("replay: %s %w, use fps pacing", b.config.Path, err)
+		}
+	}
+
+	b.src = src
+	width, height := src.Size()
+	b.pixels = make([]uint16, width*height)
+	return fmt.Sprintf("Replay of %s", b.config.Path), nil
+}
+
+// SensorSize returns the size of the recorded frames.
+func (b *Backend) SensorSize() (int, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.src == nil {
+		return 0, 0
+	}
+	return b.src.Size()
+}
+
+// Configure crops the recorded frames to the region of interest, which
+// must lie within them.
+func (b *Backend) Configure(config app.FliConfig) (app.Geometry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stop != nil {
+		return app.Geometry{}, fmt.Errorf("replay: cannot configure while acquiring")
+	}
+	if b.src == nil {
+		return app.Geometry{}, fmt.Errorf("replay: not detected")
+	}
+
+	width, height := b.src.Size()
+	if config.Width == 0 || config.Height == 0 ||
+		int64(config.OffsetX)+int64(config.Width) > int64(width) ||
+		int64(config.OffsetY)+int64(config.Height) > int64(height) {
+		return app.Geometry{}, fmt.Errorf("replay: %w: region of interest %dx%d+%d+%d outside %dx%d frames",
+			app.ErrOutOfRange, config.Width, config.Height, config.OffsetX, config.OffsetY, width, height)
+	}
+
+	b.crop = app.Geometry{
+		Width:            int32(config.Width),
+		Height:           int32(config.Height),
+		OffsetX:          int32(config.OffsetX),
+		OffsetY:          int32(config.OffsetY),
+		ImageSizeInBytes: int32(config.Width * config.Height * 2),
+	}
+	if int(config.Width) == width && int(config.Height) == height {
+		b.window = b.pixels
+	} else {
+		b.window = make([]uint16, config.Width*config.Height)
+	}
+	return b.crop, nil
+}
+
+func (b *Backend) SetFrameHandler(handler app.FrameHandler) {
+	b.handler = handler
+}
+
+func (b *Backend) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.window == nil {
+		return fmt.Errorf("replay: not configured")
+	}
+	if b.stop != nil {
+		return nil
+	}
+	b.stop = make(chan struct{})
+	b.done = make(chan struct{})
+	b.err = nil
+	go b.acquire(b.stop, b.done)
+	return nil
+}
+
+// Stop ends the replay and returns any error that interrupted it.
+func (b *Backend) Stop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stop == nil {
+		return nil
+	}
+	close(b.stop)
+	<-b.done
+	b.stop = nil
+	b.done = nil
+	return b.err
+}
+
+func (b *Backend) Shutdown() error {
+	err := b.Stop()
+	if b.src != nil {
+		b.src.Close()
+		b.src = nil
+	}
+	return err
+}
+
+func (b *Backend) acquire(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	n := b.src.Len()
+	width, _ := b.src.Size()
+
+	var ticker *time.Ticker
+	if b.config.Pacing == PacingFixed {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / b.config.FPS))
+		defer ticker.Stop()
+	}
+
+	// A loop restarts one frame period after the last frame. Configure
+	// ensures it is positive for original pacing.
+	loopPeriod := b.src.Duration()
+
+	var counter uint64
+	start := time.Now()
+	for {
+		for i := 0; i < n; i++ {
+			if err := b.src.ReadFrame(i, b.pixels); err != nil {
+				// Acquisition ends here, so the error is logged rather than
+				// only returned by the next Stop
+				b.lg.Error("Replay stopped", zap.Int("frame", i), zap.Error(err))
+				b.err = err
+				return
+			}
+			b.cropFrame(width)
+
+			switch b.config.Pacing {
+			case PacingOriginal:
+				ts, _ := b.src.Timestamp(i)
+				if !sleepUntil(stop, start.Add(ts)) {
+					return
+				}
+			case PacingFixed:
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+				}
+			case PacingFast:
+				select {
+				case <-stop:
+					return
+				default:
+				}
+			}
+
+			if b.handler != nil {
+				b.handler(unsafe.Pointer(&b.window[0]), counter)
+			}
+			counter++
+		}
+
+		if !b.config.Loop {
+			return
+		}
+		start = start.Add(loopPeriod)
+	}
+}
+
+// cropFrame copies the region of interest of the recorded frame of width
+// pixels into the window.
+func (b *Backend) cropFrame(width int) {
+	if &b.window[0] == &b.pixels[0] {
+		return
+	}
+	w := int(b.crop.Width)
+	for y := 0; y < int(b.crop.Height); y++ {
+		row := (int(b.crop.OffsetY)+y)*width + int(b.crop.OffsetX)
+		copy(b.window[y*w:(y+1)*w], b.pixels[row:row+w])
+	}
+}
+
+// checkTiming reports whether src can be replayed with its original timing.
+// Frames must advance in time, e.g. files copied without preserving their
+// modification times do not.
+func checkTiming(src source, loop bool) error {
+	if _, ok := src.Timestamp(0); !ok {
+		return errors.New("has no timing information")
+	}
+	if last, _ := src.Timestamp(src.Len() - 1); src.Len() > 1 && last <= 0 {
+		return errors.New("frame times do not advance")
+	}
+	if loop && src.Duration() <= 0 {
+		return errors.New("has no frame period to loop with")
+	}
+	return nil
+}
+
+// sleepUntil waits for t and reports false if stopped first.
+func sleepUntil(stop <-chan struct{}, t time.Time) bool {
+	d := time.Until(t)
+	if d <= 0 {
+		select {
+		case <-stop:
+			return false
+		default:
+			return true
+		}
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-stop:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,195 @@
+package replay
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/fits"
+)
+
+// source is a sequence of recorded Mono16 frames.
+type source interface {
+	Len() int
+	Size() (width, height int)
+	ReadFrame(i int, dst []uint16) error
+	// Timestamp returns the acquisition time of frame i relative to the
+	// first frame, if the recording carries timing information.
+	Timestamp(i int) (time.Duration, bool)
+	// Duration returns the time from the first frame to one frame period
+	// after the last, the period of a looped replay. It is zero if unknown.
+	Duration() time.Duration
+	Close() error
+}
+
+// Keywords holding the acquisition frame rate of a FITS cube, in order of
+// preference.
+var fpsKeywords = []string{"FRATE", "FPS", "FRAMERATE"}
+
+type fitsSource struct {
+	*fits.Image
+	period time.Duration
+}
+
+func openFits(path string) (*fitsSource, error) {
+	img, err := fits.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &fitsSource{Image: img}
+	for _, key := range fpsKeywords {
+		if fps, ok := img.Header.Float(key); ok && fps > 0 {
+			s.period = time.Duration(float64(time.Second) / fps)
+			break
+		}
+	}
+	return s, nil
+}
+
+func (s *fitsSource) Len() int {
+	return s.Frames
+}
+
+func (s *fitsSource) Size() (int, int) {
+	return s.Width, s.Height
+}
+
+func (s *fitsSource) Timestamp(i int) (time.Duration, bool) {
+	return time.Duration(i) * s.period, s.period != 0
+}
+
+func (s *fitsSource) Duration() time.Duration {
+	return time.Duration(s.Frames) * s.period
+}
+
+// dirSource replays a directory with one frame per file, either single
+// frame FITS files or raw little-endian Mono16 dumps. Files are replayed in
+// name order and timed by their modification times.
+type dirSource struct {
+	files  []string
+	times  []time.Duration
+	width  int
+	height int
+	raw    []byte
+}
+
+func openDir(path string, width, height int) (*dirSource, error) {
+	// ReadDir returns the entries sorted by name
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &dirSource{width: width, height: height}
+	var first time.Time
+	for _, e := range entries {
+		if e.IsDir() || !isFrameFile(e.Name()) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		if len(s.files) == 0 {
+			first = info.ModTime()
+		}
+		s.files = append(s.files, filepath.Join(path, e.Name()))
+		s.times = append(s.times, info.ModTime().Sub(first))
+	}
+	if len(s.files) == 0 {
+		return nil, fmt.Errorf("replay: no frames in %s", path)
+	}
+
+	// The first FITS frame sets the geometry
+	if isFits(s.files[0]) {
+		img, err := fits.Open(s.files[0])
+		if err != nil {
+			return nil, err
+		}
+		s.width, s.height = img.Width, img.Height
+		img.Close()
+	}
+	if s.width <= 0 || s.height <= 0 {
+		return nil, fmt.Errorf("replay: raw frames require a width and height")
+	}
+	s.raw = make([]byte, s.width*s.height*2)
+	return s, nil
+}
+
+func isFits(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".fits", ".fit", ".fts":
+		return true
+	}
+	return false
+}
+
+func isFrameFile(name string) bool {
+	return isFits(name) || strings.EqualFold(filepath.Ext(name), ".raw")
+}
+
+func (s *dirSource) Len() int {
+	return len(s.files)
+}
+
+func (s *dirSource) Size() (int, int) {
+	return s.width, s.height
+}
+
+func (s *dirSource) Timestamp(i int) (time.Duration, bool) {
+	return s.times[i], true
+}
+
+// Duration extends the recording by the mean frame period.
+func (s *dirSource) Duration() time.Duration {
+	n := len(s.times)
+	if n < 2 {
+		return 0
+	}
+	last := s.times[n-1]
+	return last + last/time.Duration(n-1)
+}
+
+func (s *dirSource) ReadFrame(i int, dst []uint16) error {
+	name := s.files[i]
+	if isFits(name) {
+		img, err := fits.Open(name)
+		if err != nil {
+			return err
+		}
+		defer img.Close()
+		if img.Width != s.width || img.Height != s.height || img.Frames != 1 {
+			return fmt.Errorf("replay: %s: geometry %dx%dx%d differs from %dx%d",
+				name, img.Width, img.Height, img.Frames, s.width, s.height)
+		}
+		return img.ReadFrame(0, dst)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() != int64(len(s.raw)) {
+		return fmt.Errorf("replay: %s: size %d differs from %dx%d Mono16 frame",
+			name, info.Size(), s.width, s.height)
+	}
+	if _, err := f.ReadAt(s.raw, 0); err != nil {
+		return err
+	}
+	for j := range dst[:s.width*s.height] {
+		dst[j] = binary.LittleEndian.Uint16(s.raw[2*j:])
+	}
+	return nil
+}
+
+func (s *dirSource) Close() error {
+	return nil
+}
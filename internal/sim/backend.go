@@ -0,0 +1,353 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+)
+
+const (
+	SensorWidth  = 640
+	SensorHeight = 512
+
+	bytesPerPixel = 2
+
+	minFrameRate       = 1.0
+	maxFrameRate       = 10000.0
+	minIntegrationTime = 1e-6
+)
+
+// Pattern selects the synthetic image generated by the simulator.
+type Pattern string
+
+const (
+	PatternRamp    Pattern = "ramp"
+	PatternNoise   Pattern = "noise"
+	PatternSpots   Pattern = "spots"
+	PatternCounter Pattern = "counter"
+)
+
+func ParsePattern(s string) (Pattern, error) {
+	switch p := Pattern(s); p {
+	case PatternRamp, PatternNoise, PatternSpots, PatternCounter:
+		return p, nil
+	default:
+		return "", fmt.Errorf("sim: unknown pattern: %s", s)
+	}
+}
+
+type Config struct {
+	FPS     float64
+	Pattern Pattern
+	Seed    int64
+}
+
+// Compile-time check for Backend.
+var (
+	_ app.CameraBackend       = (*Backend)(nil)
+	_ app.ExposureController  = (*Backend)(nil)
+	_ app.ThermalController   = (*Backend)(nil)
+	_ app.ReadoutModeReporter = (*Backend)(nil)
+	_ app.SensorReporter      = (*Backend)(nil)
+)
+
+// Backend is an app.CameraBackend that generates Mono16 frames in software.
+type Backend struct {
+	config  Config
+	handler app.FrameHandler
+	rng     *rand.Rand
+	tint    float64
+	thermal thermal
+
+	width   int
+	height  int
+	offsetX int
+	offsetY int
+	pixels  []uint16
+	frame   uint64
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewBackend(config Config) (*Backend, error) {
+	if math.IsNaN(config.FPS) || config.FPS < minFrameRate || config.FPS > maxFrameRate {
+		return nil, fmt.Errorf("sim: invalid frame rate: %v", config.FPS)
+	}
+	if _, err := ParsePattern(string(config.Pattern)); err != nil {
+		return nil, err
+	}
+	return &Backend{
+		config:  config,
+		rng:     rand.New(rand.NewSource(config.Seed)),
+		tint:    1 / config.FPS,
+		thermal: newThermal(),
+	}, nil
+}
+
+// Detect accepts any serial number.
+func (b *Backend) Detect(serialNumber string) (string, error) {
+	return fmt.Sprintf("Simulated %s camera", b.config.Pattern), nil
+}
+
+func (b *Backend) Configure(config app.FliConfig) (app.Geometry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stop != nil {
+		return app.Geometry{}, fmt.Errorf("sim: cannot configure while acquiring")
+	}
+	if config.Width == 0 || config.Height == 0 ||
+		int(config.OffsetX)+int(config.Width) > SensorWidth ||
+		int(config.OffsetY)+int(config.Height) > SensorHeight {
+		return app.Geometry{}, fmt.Errorf("sim: %w: cropping %dx%d+%d+%d outside %dx%d sensor",
+			app.ErrOutOfRange, config.Width, config.Height, config.OffsetX, config.OffsetY,
+			SensorWidth, SensorHeight)
+	}
+
+	b.width = int(config.Width)
+	b.height = int(config.Height)
+	b.offsetX = int(config.OffsetX)
+	b.offsetY = int(config.OffsetY)
+	b.pixels = make([]uint16, b.width*b.height)
+
+	return app.Geometry{
+		Width:            int32(b.width),
+		Height:           int32(b.height),
+		OffsetX:          int32(b.offsetX),
+		OffsetY:          int32(b.offsetY),
+		ImageSizeInBytes: int32(len(b.pixels) * bytesPerPixel),
+	}, nil
+}
+
+func (b *Backend) SensorSize() (int, int) {
+	return SensorWidth, SensorHeight
+}
+
+func (b *Backend) ReadoutMode() string {
+	return "sim-" + string(b.config.Pattern)
+}
+
+func (b *Backend) SetFrameHandler(handler app.FrameHandler) {
+	b.handler = handler
+}
+
+func (b *Backend) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pixels == nil {
+		return fmt.Errorf("sim: not configured")
+	}
+	if b.stop != nil {
+		return nil
+	}
+	b.start()
+	return nil
+}
+
+func (b *Backend) Stop() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.stop == nil {
+		return nil
+	}
+	b.halt()
+	return nil
+}
+
+func (b *Backend) start() {
+	b.stop = make(chan struct{})
+	b.done = make(chan struct{})
+	go b.acquire(b.stop, b.done, b.config.FPS)
+}
+
+func (b *Backend) halt() {
+	close(b.stop)
+	<-b.done
+	b.stop = nil
+	b.done = nil
+}
+
+func (b *Backend) Shutdown() error {
+	return b.Stop()
+}
+
+func (b *Backend) FrameRate() (app.LimitedValue, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return app.LimitedValue{Value: b.config.FPS, Min: minFrameRate, Max: maxFrameRate}, nil
+}
+
+// SetFrameRate changes the frame rate, restarting acquisition if needed.
+// The integration time is shortened to fit the new frame period.
+func (b *Backend) SetFrameRate(fps float64) (app.LimitedValue, error) {
+	if math.IsNaN(fps) || fps < minFrameRate || fps > maxFrameRate {
+		return app.LimitedValue{}, fmt.Errorf("sim: %w: frame rate %v", app.ErrOutOfRange, fps)
+	}
+	b.mu.Lock()
+	running := b.stop != nil
+	if running {
+		b.halt()
+	}
+	b.config.FPS = fps
+	if b.tint > 1/fps {
+		b.tint = 1 / fps
+	}
+	if running {
+		b.start()
+	}
+	b.mu.Unlock()
+
+	return b.FrameRate()
+}
+
+// IntegrationTime is bounded by the frame period. It is reported only and
+// does not change the generated images.
+func (b *Backend) IntegrationTime() (app.LimitedValue, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return app.LimitedValue{Value: b.tint, Min: minIntegrationTime, Max: 1 / b.config.FPS}, nil
+}
+
+func (b *Backend) SetIntegrationTime(seconds float64) (app.LimitedValue, error) {
+	b.mu.Lock()
+	b.tint = seconds
+	b.mu.Unlock()
+
+	return b.IntegrationTime()
+}
+
+func (b *Backend) acquire(stop <-chan struct{}, done chan<- struct{}, fps float64) {
+	defer close(done)
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / fps))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		b.render()
+		if b.handler != nil {
+			b.handler(unsafe.Pointer(&b.pixels[0]), b.frame)
+		}
+		b.frame++
+	}
+}
+
+func (b *Backend) render() {
+	switch b.config.Pattern {
+	case PatternRamp:
+		b.renderRamp()
+	case PatternNoise:
+		b.renderNoise()
+	case PatternSpots:
+		b.renderSpots()
+	case PatternCounter:
+		b.renderCounter()
+	}
+}
+
+// renderRamp draws a diagonal ramp in sensor coordinates that scrolls by one
+// pixel per frame.
+func (b *Backend) renderRamp() {
+	n := int(b.frame)
+	for y := 0; y < b.height; y++ {
+		row := b.pixels[y*b.width : (y+1)*b.width]
+		for x := range row {
+			row[x] = uint16((x + b.offsetX + y + b.offsetY + n) << 6)
+		}
+	}
+}
+
+const (
+	noiseMean  = 1000
+	noiseSigma = 50
+)
+
+func (b *Backend) renderNoise() {
+	for i := range b.pixels {
+		b.pixels[i] = clamp16(noiseMean + noiseSigma*b.rng.NormFloat64())
+	}
+}
+
+const (
+	numSpots      = 4
+	spotSigma     = 3.0
+	spotAmplitude = 20000
+	spotPeriod    = 200 // frames per revolution
+)
+
+// renderSpots draws gaussian spots orbiting the sensor centre on a noisy
+// background.
+func (b *Backend) renderSpots() {
+	b.renderNoise()
+
+	phase := 2 * math.Pi * float64(b.frame%spotPeriod) / spotPeriod
+	radius := 0.3 * math.Min(SensorWidth, SensorHeight)
+	extent := int(math.Ceil(4 * spotSigma))
+
+	for s := 0; s < numSpots; s++ {
+		angle := phase + 2*math.Pi*float64(s)/numSpots
+		cx := SensorWidth/2 + radius*math.Cos(angle) - float64(b.offsetX)
+		cy := SensorHeight/2 + radius*math.Sin(angle) - float64(b.offsetY)
+
+		x0, x1 := clampInt(int(cx)-extent, 0, b.width-1), clampInt(int(cx)+extent, 0, b.width-1)
+		y0, y1 := clampInt(int(cy)-extent, 0, b.height-1), clampInt(int(cy)+extent, 0, b.height-1)
+		for y := y0; y <= y1; y++ {
+			dy := float64(y) - cy
+			for x := x0; x <= x1; x++ {
+				dx := float64(x) - cx
+				v := spotAmplitude * math.Exp(-(dx*dx+dy*dy)/(2*spotSigma*spotSigma))
+				i := y*b.width + x
+				b.pixels[i] = clamp16(float64(b.pixels[i]) + v)
+			}
+		}
+	}
+}
+
+// renderCounter stores the 64-bit frame counter little-endian in the first
+// four pixels and fills the rest of the frame with its low 16 bits.
+func (b *Backend) renderCounter() {
+	low := uint16(b.frame)
+	for i := range b.pixels {
+		b.pixels[i] = low
+	}
+	for i := 0; i < 4 && i < len(b.pixels); i++ {
+		b.pixels[i] = uint16(b.frame >> (16 * i))
+	}
+}
+
+func clamp16(v float64) uint16 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= math.MaxUint16:
+		return math.MaxUint16
+	default:
+		return uint16(v)
+	}
+}
+
+func clampInt(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,77 @@
+package sim
+
+import (
+	"math"
+	"time"
+
+	"github.com/New-Earth-Lab/flicameraservice/internal/app"
+)
+
+const (
+	ambientTemperature  = 20.0
+	coolingTimeConstant = 60 * time.Second
+
+	minSensorSetpoint = -40.0
+	maxSensorSetpoint = 20.0
+)
+
+// thermal models the sensor approaching its setpoint exponentially.
+type thermal struct {
+	setpoint float64
+	start    float64
+	since    time.Time
+}
+
+func newThermal() thermal {
+	return thermal{
+		setpoint: ambientTemperature,
+		start:    ambientTemperature,
+		since:    time.Now(),
+	}
+}
+
+func (t *thermal) sensor(now time.Time) float64 {
+	decay := math.Exp(-now.Sub(t.since).Seconds() / coolingTimeConstant.Seconds())
+	return t.setpoint + (t.start-t.setpoint)*decay
+}
+
+func (t *thermal) setSetpoint(now time.Time, celsius float64) {
+	t.start = t.sensor(now)
+	t.since = now
+	t.setpoint = celsius
+}
+
+func (b *Backend) Temperatures() (app.Temperatures, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sensor := b.thermal.sensor(time.Now())
+	power := 100 * (ambientTemperature - sensor) / (ambientTemperature - minSensorSetpoint)
+	power = math.Max(0, math.Min(100, power))
+	return app.Temperatures{
+		Sensor:      sensor,
+		Motherboard: ambientTemperature + 15,
+		Peltier:     sensor - 2,
+		Heatsink:    ambientTemperature + 5 + power/10,
+		TECPower:    power,
+	}, nil
+}
+
+func (b *Backend) SensorSetpoint() (app.LimitedValue, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return app.LimitedValue{
+		Value: b.thermal.setpoint,
+		Min:   minSensorSetpoint,
+		Max:   maxSensorSetpoint,
+	}, nil
+}
+
+func (b *Backend) SetSensorSetpoint(celsius float64) (app.LimitedValue, error) {
+	b.mu.Lock()
+	b.thermal.setSetpoint(time.Now(), celsius)
+	b.mu.Unlock()
+
+	return b.SensorSetpoint()
+}
@@ -0,0 +1,802 @@
+// Command sbegen generates Go flyweights on aeron-go buffers from a Simple
+// Binary Encoding message schema.
+//
+// It supports the subset of SBE the service schemas use: little-endian
+// schemas of primitive fields, enums, composites of primitive types and
+// variable length data, with field offsets and sinceVersion. Repeating
+// groups, constants, arrays and char enums are rejected, as are enum
+// encodings the SBE tool does not accept.
+//
+//	go run ./internal/tools/sbegen -o pkg/framecodec/frame_gen.go _sbe/frame.xml
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+func main() {
+	out := flag.String("o", "", "output file, standard output if empty")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: sbegen [-o file.go] schema.xml")
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), *out); err != nil {
+		fmt.Fprintln(os.Stderr, "sbegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(path, out string) error {
+	code, err := generate(path)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(out, code, 0o644)
+}
+
+// generate returns the formatted Go code for the schema at path.
+func generate(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s xmlSchema
+	if err := xml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	model, err := resolve(&s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	model.Source = filepath.Base(path)
+
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, model); err != nil {
+		return nil, err
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return code, nil
+}
+
+// XML schema
+
+type xmlSchema struct {
+	Package     string       `xml:"package,attr"`
+	ID          uint16       `xml:"id,attr"`
+	Version     uint16       `xml:"version,attr"`
+	ByteOrder   string       `xml:"byteOrder,attr"`
+	Description string       `xml:"description,attr"`
+	Types       []xmlTypes   `xml:"types"`
+	Messages    []xmlMessage `xml:"message"`
+}
+
+type xmlTypes struct {
+	Types      []xmlType      `xml:"type"`
+	Composites []xmlComposite `xml:"composite"`
+	Enums      []xmlEnum      `xml:"enum"`
+}
+
+type xmlType struct {
+	Name          string `xml:"name,attr"`
+	PrimitiveType string `xml:"primitiveType,attr"`
+	Length        string `xml:"length,attr"`
+	Presence      string `xml:"presence,attr"`
+	Description   string `xml:"description,attr"`
+}
+
+type xmlComposite struct {
+	Name        string    `xml:"name,attr"`
+	Description string    `xml:"description,attr"`
+	Types       []xmlType `xml:"type"`
+}
+
+type xmlEnum struct {
+	Name         string          `xml:"name,attr"`
+	EncodingType string          `xml:"encodingType,attr"`
+	Description  string          `xml:"description,attr"`
+	ValidValues  []xmlValidValue `xml:"validValue"`
+}
+
+type xmlValidValue struct {
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type xmlMessage struct {
+	Name         string     `xml:"name,attr"`
+	ID           uint16     `xml:"id,attr"`
+	Description  string     `xml:"description,attr"`
+	BlockLength  int32      `xml:"blockLength,attr"`
+	SinceVersion uint16     `xml:"sinceVersion,attr"`
+	Fields       []xmlField `xml:"field"`
+	Data         []xmlField `xml:"data"`
+	Groups       []xmlField `xml:"group"`
+}
+
+type xmlField struct {
+	Name         string `xml:"name,attr"`
+	ID           uint16 `xml:"id,attr"`
+	Type         string `xml:"type,attr"`
+	Offset       string `xml:"offset,attr"`
+	SinceVersion uint16 `xml:"sinceVersion,attr"`
+	Presence     string `xml:"presence,attr"`
+	Description  string `xml:"description,attr"`
+}
+
+// Generator model
+
+// primitive describes how a primitive type is read from and written to an
+// atomic.Buffer, which only has native accessors for some of them.
+type primitive struct {
+	GoType string
+	Size   int32
+	// get and put are format strings of the buffer offset, and of the
+	// offset and value for put.
+	get, put string
+	Null     string
+}
+
+var primitives = map[string]primitive{
+	"char":   {"byte", 1, "m.buf.GetUInt8(%s)", "m.buf.PutUInt8(%s, %s)", "0"},
+	"int8":   {"int8", 1, "int8(m.buf.GetUInt8(%s))", "m.buf.PutInt8(%s, %s)", "math.MinInt8"},
+	"uint8":  {"uint8", 1, "m.buf.GetUInt8(%s)", "m.buf.PutUInt8(%s, %s)", "math.MaxUint8"},
+	"int16":  {"int16", 2, "int16(m.buf.GetUInt16(%s))", "m.buf.PutUInt16(%s, uint16(%s))", "math.MinInt16"},
+	"uint16": {"uint16", 2, "m.buf.GetUInt16(%s)", "m.buf.PutUInt16(%s, %s)", "math.MaxUint16"},
+	"int32":  {"int32", 4, "m.buf.GetInt32(%s)", "m.buf.PutInt32(%s, %s)", "math.MinInt32"},
+	"uint32": {"uint32", 4, "uint32(m.buf.GetInt32(%s))", "m.buf.PutInt32(%s, int32(%s))", "math.MaxUint32"},
+	"int64":  {"int64", 8, "m.buf.GetInt64(%s)", "m.buf.PutInt64(%s, %s)", "math.MinInt64"},
+	"uint64": {"uint64", 8, "uint64(m.buf.GetInt64(%s))", "m.buf.PutInt64(%s, int64(%s))", "math.MaxUint64"},
+	"float": {"float32", 4, "math.Float32frombits(uint32(m.buf.GetInt32(%s)))",
+		"m.buf.PutInt32(%s, int32(math.Float32bits(%s)))", "float32(math.NaN())"},
+	"double": {"float64", 8, "math.Float64frombits(uint64(m.buf.GetInt64(%s)))",
+		"m.buf.PutInt64(%s, int64(math.Float64bits(%s)))", "math.NaN()"},
+}
+
+// enumEncodings are the integer encoding types the SBE tool accepts for
+// enums.
+var enumEncodings = map[string]bool{
+	"int8":   true,
+	"uint8":  true,
+	"int16":  true,
+	"uint16": true,
+	"int32":  true,
+}
+
+type model struct {
+	Source     string
+	NeedFmt    bool
+	NeedMath   bool
+	Package    string
+	ID         uint16
+	Version    uint16
+	Enums      []*enum
+	Composites []*composite
+	Messages   []*message
+}
+
+type enum struct {
+	Name   string
+	Doc    string
+	GoType string
+	Null   string
+	Values []enumValue
+}
+
+type enumValue struct {
+	Name  string
+	Doc   string
+	Value string
+}
+
+type composite struct {
+	Name          string
+	Doc           string
+	EncodedLength int32
+	Fields        []*field
+}
+
+// field is a fixed length field of a message block or composite.
+type field struct {
+	Name   string
+	Doc    string
+	GoType string
+	Offset int32
+	Since  uint16
+	Null   string
+	// Get and Put are the accessor expressions, with the value v for Put.
+	Get, Put string
+}
+
+type varData struct {
+	Name         string
+	Doc          string
+	Since        uint16
+	HeaderLength int32
+	// GetLength reads the length prefix at m.limit as an uint32 and
+	// PutLength writes n to it.
+	GetLength, PutLength string
+}
+
+type message struct {
+	Name        string
+	Doc         string
+	ID          uint16
+	BlockLength int32
+	Fields      []*field
+	Data        []*varData
+}
+
+// goName converts an SBE name to an exported Go identifier, with Go
+// initialisms for Id.
+func goName(name string) string {
+	r := []rune(name)
+	if len(r) == 0 {
+		return name
+	}
+	r[0] = unicode.ToUpper(r[0])
+	s := string(r)
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.HasPrefix(s[i:], "Id") && (i+2 == len(s) || unicode.IsUpper(rune(s[i+2]))) {
+			b.WriteString("ID")
+			i++
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// doc returns a sentence documenting name from an SBE description.
+func doc(name, verb, description, fallback string) string {
+	if description == "" {
+		return name + " " + fallback
+	}
+	return name + " " + verb + " " + strings.TrimSuffix(lowerFirst(description), ".") + "."
+}
+
+// lowerFirst lowercases the first letter of a description, unless it
+// starts an acronym.
+func lowerFirst(s string) string {
+	r := []rune(s)
+	if len(r) > 1 && unicode.IsUpper(r[1]) {
+		return s
+	}
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func resolve(s *xmlSchema) (*model, error) {
+	if s.ByteOrder != "" && s.ByteOrder != "littleEndian" {
+		return nil, fmt.Errorf("unsupported byte order %s", s.ByteOrder)
+	}
+	if s.Package == "" {
+		return nil, fmt.Errorf("schema has no package")
+	}
+	m := &model{
+		Package: s.Package[strings.LastIndex(s.Package, ".")+1:],
+		ID:      s.ID,
+		Version: s.Version,
+	}
+
+	types := make(map[string]xmlType)
+	enums := make(map[string]*enum)
+	varDataTypes := make(map[string]xmlComposite)
+	for _, ts := range s.Types {
+		for _, t := range ts.Types {
+			types[t.Name] = t
+		}
+		for _, e := range ts.Enums {
+			if !enumEncodings[e.EncodingType] {
+				return nil, fmt.Errorf("enum %s: unsupported encoding type %s", e.Name, e.EncodingType)
+			}
+			p := primitives[e.EncodingType]
+			name := goName(e.Name)
+			ge := &enum{
+				Name:   name,
+				Doc:    doc(name, "is the", e.Description, "is an enumeration."),
+				GoType: p.GoType,
+				Null:   p.Null,
+			}
+			if len(e.ValidValues) == 0 {
+				return nil, fmt.Errorf("enum %s: no valid values", e.Name)
+			}
+			for _, v := range e.ValidValues {
+				value := strings.TrimSpace(v.Value)
+				var err error
+				if strings.HasPrefix(p.GoType, "u") {
+					_, err = strconv.ParseUint(value, 0, int(p.Size)*8)
+				} else {
+					_, err = strconv.ParseInt(value, 0, int(p.Size)*8)
+				}
+				if err != nil {
+					return nil, fmt.Errorf("enum %s: value %s: %w", e.Name, v.Name, err)
+				}
+				vname := name + goName(v.Name)
+				ge.Values = append(ge.Values, enumValue{
+					Name:  vname,
+					Doc:   doc(vname, "is", v.Description, "is a valid value."),
+					Value: value,
+				})
+			}
+			enums[e.Name] = ge
+			m.Enums = append(m.Enums, ge)
+		}
+		for _, c := range ts.Composites {
+			if isVarData(c) {
+				varDataTypes[c.Name] = c
+				continue
+			}
+			name := goName(c.Name)
+			gc := &composite{Name: name, Doc: doc(name, "is the", c.Description, "is a composite.")}
+			for _, t := range c.Types {
+				f, err := primitiveField(t.Name, t, gc.EncodedLength, 0)
+				if err != nil {
+					return nil, fmt.Errorf("composite %s: %w", c.Name, err)
+				}
+				gc.Fields = append(gc.Fields, f)
+				gc.EncodedLength += primitives[t.PrimitiveType].Size
+			}
+			m.Composites = append(m.Composites, gc)
+		}
+	}
+	if !hasComposite(m, "MessageHeader") {
+		return nil, fmt.Errorf("schema has no messageHeader composite")
+	}
+
+	for _, xm := range s.Messages {
+		if len(xm.Groups) > 0 {
+			return nil, fmt.Errorf("message %s: repeating groups are not supported", xm.Name)
+		}
+		name := goName(xm.Name)
+		gm := &message{Name: name, Doc: doc(name, "is the", xm.Description, "is a message."), ID: xm.ID}
+		offset := int32(0)
+		for _, xf := range xm.Fields {
+			if xf.Presence == "constant" {
+				return nil, fmt.Errorf("message %s: field %s: constants are not supported", xm.Name, xf.Name)
+			}
+			if xf.Offset != "" {
+				o, err := strconv.ParseInt(xf.Offset, 10, 32)
+				if err != nil || int32(o) < offset {
+					return nil, fmt.Errorf("message %s: field %s: invalid offset %s", xm.Name, xf.Name, xf.Offset)
+				}
+				offset = int32(o)
+			}
+			var f *field
+			var size int32
+			if e, ok := enums[xf.Type]; ok {
+				p := primitives[findEncoding(s, xf.Type)]
+				f = &field{
+					GoType: e.Name,
+					Null:   e.Name + "NullValue",
+					Get:    e.Name + "(" + fmt.Sprintf(p.get, offsetExpr(offset)) + ")",
+					Put:    fmt.Sprintf(p.put, offsetExpr(offset), p.GoType+"(v)"),
+				}
+				size = p.Size
+			} else {
+				t, ok := types[xf.Type]
+				if !ok {
+					t = xmlType{PrimitiveType: xf.Type}
+				}
+				var err error
+				if f, err = primitiveField(xf.Name, t, offset, xf.SinceVersion); err != nil {
+					return nil, fmt.Errorf("message %s: %w", xm.Name, err)
+				}
+				size = primitives[t.PrimitiveType].Size
+			}
+			f.Name = goName(xf.Name)
+			f.Since = xf.SinceVersion
+			f.Offset = offset
+			f.Doc = fieldDoc(f.Name, xf)
+			gm.Fields = append(gm.Fields, f)
+			offset += size
+		}
+		gm.BlockLength = offset
+		if xm.BlockLength > 0 {
+			if xm.BlockLength < offset {
+				return nil, fmt.Errorf("message %s: block length %d is shorter than its fields", xm.Name, xm.BlockLength)
+			}
+			gm.BlockLength = xm.BlockLength
+		}
+		for _, xd := range xm.Data {
+			c, ok := varDataTypes[xd.Type]
+			if !ok {
+				return nil, fmt.Errorf("message %s: data %s: unknown variable length encoding %s", xm.Name, xd.Name, xd.Type)
+			}
+			p := primitives[c.Types[0].PrimitiveType]
+			getLength, n := fmt.Sprintf(p.get, "m.limit"), "n"
+			if p.GoType != "uint32" {
+				getLength, n = "uint32("+getLength+")", p.GoType+"(n)"
+			}
+			gm.Data = append(gm.Data, &varData{
+				Name:         goName(xd.Name),
+				Doc:          fieldDoc(goName(xd.Name), xd),
+				Since:        xd.SinceVersion,
+				HeaderLength: p.Size,
+				GetLength:    getLength,
+				PutLength:    fmt.Sprintf(p.put, "m.limit", n),
+			})
+		}
+		m.Messages = append(m.Messages, gm)
+	}
+	m.NeedFmt, m.NeedMath = imports(m)
+	return m, nil
+}
+
+// imports reports whether the generated code uses fmt and math.
+func imports(m *model) (needFmt, needMath bool) {
+	uses := func(expr string) {
+		needMath = needMath || strings.Contains(expr, "math.")
+	}
+	for _, e := range m.Enums {
+		needFmt = true
+		uses(e.Null)
+	}
+	for _, c := range m.Composites {
+		for _, f := range c.Fields {
+			uses(f.Get)
+			uses(f.Put)
+		}
+	}
+	for _, msg := range m.Messages {
+		for _, f := range msg.Fields {
+			uses(f.Get)
+			uses(f.Put)
+			if f.Since > 0 {
+				uses(f.Null)
+			}
+		}
+	}
+	return needFmt, needMath
+}
+
+func primitiveField(name string, t xmlType, offset int32, since uint16) (*field, error) {
+	p, ok := primitives[t.PrimitiveType]
+	if !ok {
+		return nil, fmt.Errorf("field %s: unsupported type %s", name, t.PrimitiveType)
+	}
+	if t.Length != "" && t.Length != "1" {
+		return nil, fmt.Errorf("field %s: arrays are not supported", name)
+	}
+	if t.Presence == "constant" {
+		return nil, fmt.Errorf("field %s: constants are not supported", name)
+	}
+	return &field{
+		Name:   goName(name),
+		Doc:    goName(name) + " returns the " + name + " field.",
+		GoType: p.GoType,
+		Offset: offset,
+		Since:  since,
+		Null:   p.Null,
+		Get:    fmt.Sprintf(p.get, offsetExpr(offset)),
+		Put:    fmt.Sprintf(p.put, offsetExpr(offset), "v"),
+	}, nil
+}
+
+func fieldDoc(name string, f xmlField) string {
+	text := name + " returns the " + f.Name + " field"
+	if f.Description != "" {
+		text += ", the " + strings.TrimSuffix(lowerFirst(f.Description), ".")
+	}
+	text += fmt.Sprintf(" (id %d)", f.ID)
+	if f.SinceVersion > 0 {
+		text += fmt.Sprintf(", added in schema version %d", f.SinceVersion)
+	}
+	return text + "."
+}
+
+func offsetExpr(offset int32) string {
+	if offset == 0 {
+		return "m.offset"
+	}
+	return fmt.Sprintf("m.offset+%d", offset)
+}
+
+// isVarData reports whether c is a variable length data encoding: a length
+// followed by a zero length varData.
+func isVarData(c xmlComposite) bool {
+	if len(c.Types) != 2 || c.Types[0].Name != "length" || c.Types[1].Name != "varData" {
+		return false
+	}
+	switch c.Types[0].PrimitiveType {
+	case "uint8", "uint16", "uint32":
+		return c.Types[1].Length == "0"
+	}
+	return false
+}
+
+func findEncoding(s *xmlSchema, name string) string {
+	for _, ts := range s.Types {
+		for _, e := range ts.Enums {
+			if e.Name == name {
+				return e.EncodingType
+			}
+		}
+	}
+	return ""
+}
+
+func hasComposite(m *model, name string) bool {
+	for _, c := range m.Composites {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// comment formats text as a Go comment wrapped at 77 columns.
+func comment(text string) string {
+	var lines []string
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 77 && line != "//" {
+			lines = append(lines, line)
+			line = "//"
+		}
+		line += " " + word
+	}
+	return strings.Join(append(lines, line), "\n")
+}
+
+var codeTemplate = template.Must(template.New("code").Funcs(template.FuncMap{
+	"comment": comment,
+}).Parse(`// Code generated by sbegen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .NeedFmt}}
+	"fmt"
+{{- end}}
+{{- if .NeedMath}}
+	"math"
+{{- end}}
+
+	"github.com/lirm/aeron-go/aeron/atomic"
+)
+
+const (
+	// SchemaID identifies the message schema.
+	SchemaID uint16 = {{.ID}}
+	// SchemaVersion is the version of the schema the codecs encode.
+	SchemaVersion uint16 = {{.Version}}
+)
+{{range .Enums}}{{$e := .}}
+{{comment .Doc}}
+type {{.Name}} {{.GoType}}
+
+const (
+{{- range .Values}}
+	{{comment .Doc}}
+	{{.Name}} {{$e.Name}} = {{.Value}}
+{{- end}}
+	// {{.Name}}NullValue is the value of an absent field.
+	{{.Name}}NullValue {{.Name}} = {{.Null}}
+)
+
+// IsValid reports whether v is a value of the schema.
+func (v {{.Name}}) IsValid() bool {
+	switch v {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+
+func (v {{.Name}}) String() string {
+	switch v {
+{{- range .Values}}
+	case {{.Name}}:
+		return "{{.Name}}"
+{{- end}}
+	case {{.Name}}NullValue:
+		return "{{.Name}}NullValue"
+	}
+	return fmt.Sprintf("{{.Name}}(%d)", {{.GoType}}(v))
+}
+{{end}}
+{{range .Composites}}
+// {{.Name}}EncodedLength is the length of an encoded {{.Name}}.
+const {{.Name}}EncodedLength = {{.EncodedLength}}
+
+{{comment .Doc}}
+type {{.Name}} struct {
+	buf    *atomic.Buffer
+	offset int32
+}
+
+// Wrap wraps m around the composite at offset in buf.
+func (m *{{.Name}}) Wrap(buf *atomic.Buffer, offset int32) *{{.Name}} {
+	m.buf = buf
+	m.offset = offset
+	return m
+}
+{{$c := .}}{{range .Fields}}
+{{comment .Doc}}
+func (m *{{$c.Name}}) {{.Name}}() {{.GoType}} {
+	return {{.Get}}
+}
+
+// Set{{.Name}} sets the {{.Name}} field.
+func (m *{{$c.Name}}) Set{{.Name}}(v {{.GoType}}) {
+	{{.Put}}
+}
+{{end}}{{end}}
+{{range .Messages}}{{$m := .}}
+const (
+	// {{.Name}}TemplateID identifies {{.Name}} messages in the MessageHeader.
+	{{.Name}}TemplateID uint16 = {{.ID}}
+	// {{.Name}}BlockLength is the length of the fixed fields of {{.Name}}.
+	{{.Name}}BlockLength = {{.BlockLength}}
+{{- range .Data}}
+	// {{$m.Name}}{{.Name}}HeaderLength is the length of the {{.Name}} length prefix.
+	{{$m.Name}}{{.Name}}HeaderLength = {{.HeaderLength}}
+{{- end}}
+)
+
+{{comment .Doc}}
+//
+// The fixed fields can be accessed in any order. The variable length fields
+// must be accessed in schema order, each one advancing the limit of the
+// message past its data.
+type {{.Name}} struct {
+	buf           *atomic.Buffer
+	offset        int32
+	limit         int32
+	actingVersion uint16
+}
+
+// WrapForEncode wraps m around the message at offset in buf for encoding.
+func (m *{{.Name}}) WrapForEncode(buf *atomic.Buffer, offset int32) *{{.Name}} {
+	m.buf = buf
+	m.offset = offset
+	m.limit = offset + {{.Name}}BlockLength
+	m.actingVersion = SchemaVersion
+	return m
+}
+
+// WrapAndApplyHeader encodes a MessageHeader at offset in buf with hdr and
+// wraps m around the message that follows it for encoding.
+func (m *{{.Name}}) WrapAndApplyHeader(buf *atomic.Buffer, offset int32, hdr *MessageHeader) *{{.Name}} {
+	hdr.Wrap(buf, offset)
+	hdr.SetBlockLength({{.Name}}BlockLength)
+	hdr.SetTemplateID({{.Name}}TemplateID)
+	hdr.SetSchemaID(SchemaID)
+	hdr.SetVersion(SchemaVersion)
+	return m.WrapForEncode(buf, offset+MessageHeaderEncodedLength)
+}
+
+// WrapForDecode wraps m around the message at offset in buf, encoded with
+// the block length and schema version of its MessageHeader.
+func (m *{{.Name}}) WrapForDecode(buf *atomic.Buffer, offset, actingBlockLength int32, actingVersion uint16) *{{.Name}} {
+	m.buf = buf
+	m.offset = offset
+	m.limit = offset + actingBlockLength
+	m.actingVersion = actingVersion
+	return m
+}
+
+// Offset returns the offset of the message in its buffer.
+func (m *{{.Name}}) Offset() int32 {
+	return m.offset
+}
+
+// Limit returns the offset in the buffer the next variable length field
+// starts at, or the end of the message once they have all been accessed.
+func (m *{{.Name}}) Limit() int32 {
+	return m.limit
+}
+
+// SetLimit sets the offset the next variable length field starts at.
+func (m *{{.Name}}) SetLimit(limit int32) {
+	m.limit = limit
+}
+
+// EncodedLength returns the length of the message up to its limit.
+func (m *{{.Name}}) EncodedLength() int32 {
+	return m.limit - m.offset
+}
+
+// ActingVersion returns the schema version the message is decoded with.
+func (m *{{.Name}}) ActingVersion() uint16 {
+	return m.actingVersion
+}
+{{range .Fields}}
+{{comment .Doc}}
+func (m *{{$m.Name}}) {{.Name}}() {{.GoType}} {
+{{- if .Since}}
+	if m.actingVersion < {{.Since}} {
+		return {{.Null}}
+	}
+{{- end}}
+	return {{.Get}}
+}
+
+// Set{{.Name}} sets the {{.Name}} field.
+func (m *{{$m.Name}}) Set{{.Name}}(v {{.GoType}}) {
+	{{.Put}}
+}
+{{end}}
+{{range .Data}}
+// {{.Name}}Length returns the length of the {{.Name}} field at the limit,
+// without advancing it.
+func (m *{{$m.Name}}) {{.Name}}Length() uint32 {
+{{- if .Since}}
+	if m.actingVersion < {{.Since}} {
+		return 0
+	}
+{{- end}}
+	return {{.GetLength}}
+}
+
+// Skip{{.Name}} advances the limit past the {{.Name}} field and returns its
+// length.
+func (m *{{$m.Name}}) Skip{{.Name}}() uint32 {
+{{- if .Since}}
+	if m.actingVersion < {{.Since}} {
+		return 0
+	}
+{{- end}}
+	n := {{.GetLength}}
+	m.limit += {{$m.Name}}{{.Name}}HeaderLength + int32(n)
+	return n
+}
+
+{{comment .Doc}}
+//
+// The returned slice aliases the buffer. It is nil if the field extends past
+// the buffer, in which case the limit is past the buffer too.
+func (m *{{$m.Name}}) {{.Name}}() []byte {
+{{- if .Since}}
+	if m.actingVersion < {{.Since}} {
+		return nil
+	}
+{{- end}}
+	n := int64({{.GetLength}})
+	offset := m.limit + {{$m.Name}}{{.Name}}HeaderLength
+	if int64(offset)+n > int64(m.buf.Capacity()) {
+		m.limit = m.buf.Capacity() + 1
+		return nil
+	}
+	m.limit = offset + int32(n)
+	return m.buf.GetBytesArray(offset, int32(n))
+}
+
+// Put{{.Name}} encodes the {{.Name}} field from src at the limit.
+func (m *{{$m.Name}}) Put{{.Name}}(src []byte) {
+	offset := m.Put{{.Name}}Length(uint32(len(src)))
+	if len(src) > 0 {
+		m.buf.PutBytesArray(offset, &src, 0, int32(len(src)))
+	}
+}
+
+// Put{{.Name}}Length encodes the length prefix of the {{.Name}} field for n
+// bytes at the limit, advances the limit past the field and returns the
+// offset of its data, which the caller writes or sends separately.
+func (m *{{$m.Name}}) Put{{.Name}}Length(n uint32) int32 {
+	{{.PutLength}}
+	offset := m.limit + {{$m.Name}}{{.Name}}HeaderLength
+	m.limit = offset + int32(n)
+	return offset
+}
+{{end}}{{end}}`))
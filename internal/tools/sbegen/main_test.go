@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedCodeIsCurrent(t *testing.T) {
+	code, err := generate(filepath.Join("..", "..", "..", "_sbe", "frame.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkedIn, err := os.ReadFile(filepath.Join("..", "..", "..", "pkg", "framecodec", "frame_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(code, checkedIn) {
+		t.Error("pkg/framecodec/frame_gen.go is out of date with _sbe/frame.xml, run go generate")
+	}
+}
+
+// TestEnumEncodings checks that enums are only generated for the encoding
+// types and values the SBE tool accepts.
+func TestEnumEncodings(t *testing.T) {
+	tests := []struct {
+		encoding, value string
+		ok              bool
+	}{
+		{"int8", "-128", true},
+		{"uint8", "254", true},
+		{"int16", "1000", true},
+		{"uint16", "65000", true},
+		{"int32", "17825799", true},
+		{"uint8", "256", false},
+		{"int8", "128", false},
+		{"uint16", "-1", false},
+		{"int32", "2147483648", false},
+		{"char", "65", false},
+		{"uint32", "17825799", false},
+		{"int64", "0", false},
+		{"uint64", "0", false},
+		{"float", "0", false},
+	}
+	for _, tt := range tests {
+		schema := `<messageSchema package="test" id="1" version="1">
+	<types>
+		<composite name="messageHeader">
+			<type name="blockLength" primitiveType="uint16"/>
+			<type name="templateId" primitiveType="uint16"/>
+			<type name="schemaId" primitiveType="uint16"/>
+			<type name="version" primitiveType="uint16"/>
+		</composite>
+		<enum name="E" encodingType="` + tt.encoding + `">
+			<validValue name="V">` + tt.value + `</validValue>
+		</enum>
+	</types>
+</messageSchema>`
+		var s xmlSchema
+		if err := xml.NewDecoder(strings.NewReader(schema)).Decode(&s); err != nil {
+			t.Fatal(err)
+		}
+		_, err := resolve(&s)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s enum value %s: error %v, want ok %v", tt.encoding, tt.value, err, tt.ok)
+		}
+	}
+}
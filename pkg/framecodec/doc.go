@@ -0,0 +1,24 @@
+// Package framecodec encodes and decodes the frames published by the FLI
+// camera service.
+//
+// The codecs are generated from the SBE message schema in _sbe/frame.xml.
+// Every message is a MessageHeader followed by an ImageFrame: its fixed
+// fields, then the metadata and image variable length fields. Subscribers
+// in other languages can generate matching decoders from the same schema
+// with the SBE tool.
+//
+// Decoders check the schema and template ids of the MessageHeader and wrap
+// the ImageFrame with its block length and version, so that fields added in
+// later schema versions do not break them:
+//
+//	var hdr framecodec.MessageHeader
+//	var frame framecodec.ImageFrame
+//	hdr.Wrap(buf, offset)
+//	if hdr.SchemaID() != framecodec.SchemaID || hdr.TemplateID() != framecodec.ImageFrameTemplateID {
+//		return
+//	}
+//	frame.WrapForDecode(buf, offset+framecodec.MessageHeaderEncodedLength,
+//		int32(hdr.BlockLength()), hdr.Version())
+//	metadata := frame.Metadata()
+//	pixels := frame.Image()
+package framecodec
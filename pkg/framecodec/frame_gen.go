@@ -0,0 +1,412 @@
+// Code generated by sbegen from frame.xml. DO NOT EDIT.
+
+package framecodec
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lirm/aeron-go/aeron/atomic"
+)
+
+const (
+	// SchemaID identifies the message schema.
+	SchemaID uint16 = 1001
+	// SchemaVersion is the version of the schema the codecs encode.
+	SchemaVersion uint16 = 1
+)
+
+// PayloadType is the content of the frame.
+type PayloadType int32
+
+const (
+	// PayloadTypeImage is a camera image.
+	PayloadTypeImage PayloadType = 0
+	// PayloadTypeNullValue is the value of an absent field.
+	PayloadTypeNullValue PayloadType = math.MinInt32
+)
+
+// IsValid reports whether v is a value of the schema.
+func (v PayloadType) IsValid() bool {
+	switch v {
+	case PayloadTypeImage:
+		return true
+	}
+	return false
+}
+
+func (v PayloadType) String() string {
+	switch v {
+	case PayloadTypeImage:
+		return "PayloadTypeImage"
+	case PayloadTypeNullValue:
+		return "PayloadTypeNullValue"
+	}
+	return fmt.Sprintf("PayloadType(%d)", int32(v))
+}
+
+// PixelFormat is the pixel format code of the GenICam naming convention.
+type PixelFormat int32
+
+const (
+	// PixelFormatMono16 is 16-bit monochrome.
+	PixelFormatMono16 PixelFormat = 17825799
+	// PixelFormatNullValue is the value of an absent field.
+	PixelFormatNullValue PixelFormat = math.MinInt32
+)
+
+// IsValid reports whether v is a value of the schema.
+func (v PixelFormat) IsValid() bool {
+	switch v {
+	case PixelFormatMono16:
+		return true
+	}
+	return false
+}
+
+func (v PixelFormat) String() string {
+	switch v {
+	case PixelFormatMono16:
+		return "PixelFormatMono16"
+	case PixelFormatNullValue:
+		return "PixelFormatNullValue"
+	}
+	return fmt.Sprintf("PixelFormat(%d)", int32(v))
+}
+
+// MessageHeaderEncodedLength is the length of an encoded MessageHeader.
+const MessageHeaderEncodedLength = 8
+
+// MessageHeader is the message identifiers and length of message root.
+type MessageHeader struct {
+	buf    *atomic.Buffer
+	offset int32
+}
+
+// Wrap wraps m around the composite at offset in buf.
+func (m *MessageHeader) Wrap(buf *atomic.Buffer, offset int32) *MessageHeader {
+	m.buf = buf
+	m.offset = offset
+	return m
+}
+
+// BlockLength returns the blockLength field.
+func (m *MessageHeader) BlockLength() uint16 {
+	return m.buf.GetUInt16(m.offset)
+}
+
+// SetBlockLength sets the BlockLength field.
+func (m *MessageHeader) SetBlockLength(v uint16) {
+	m.buf.PutUInt16(m.offset, v)
+}
+
+// TemplateID returns the templateId field.
+func (m *MessageHeader) TemplateID() uint16 {
+	return m.buf.GetUInt16(m.offset + 2)
+}
+
+// SetTemplateID sets the TemplateID field.
+func (m *MessageHeader) SetTemplateID(v uint16) {
+	m.buf.PutUInt16(m.offset+2, v)
+}
+
+// SchemaID returns the schemaId field.
+func (m *MessageHeader) SchemaID() uint16 {
+	return m.buf.GetUInt16(m.offset + 4)
+}
+
+// SetSchemaID sets the SchemaID field.
+func (m *MessageHeader) SetSchemaID(v uint16) {
+	m.buf.PutUInt16(m.offset+4, v)
+}
+
+// Version returns the version field.
+func (m *MessageHeader) Version() uint16 {
+	return m.buf.GetUInt16(m.offset + 6)
+}
+
+// SetVersion sets the Version field.
+func (m *MessageHeader) SetVersion(v uint16) {
+	m.buf.PutUInt16(m.offset+6, v)
+}
+
+const (
+	// ImageFrameTemplateID identifies ImageFrame messages in the MessageHeader.
+	ImageFrameTemplateID uint16 = 1
+	// ImageFrameBlockLength is the length of the fixed fields of ImageFrame.
+	ImageFrameBlockLength = 48
+	// ImageFrameMetadataHeaderLength is the length of the Metadata length prefix.
+	ImageFrameMetadataHeaderLength = 4
+	// ImageFrameImageHeaderLength is the length of the Image length prefix.
+	ImageFrameImageHeaderLength = 4
+)
+
+// ImageFrame is the camera frame.
+//
+// The fixed fields can be accessed in any order. The variable length fields
+// must be accessed in schema order, each one advancing the limit of the
+// message past its data.
+type ImageFrame struct {
+	buf           *atomic.Buffer
+	offset        int32
+	limit         int32
+	actingVersion uint16
+}
+
+// WrapForEncode wraps m around the message at offset in buf for encoding.
+func (m *ImageFrame) WrapForEncode(buf *atomic.Buffer, offset int32) *ImageFrame {
+	m.buf = buf
+	m.offset = offset
+	m.limit = offset + ImageFrameBlockLength
+	m.actingVersion = SchemaVersion
+	return m
+}
+
+// WrapAndApplyHeader encodes a MessageHeader at offset in buf with hdr and
+// wraps m around the message that follows it for encoding.
+func (m *ImageFrame) WrapAndApplyHeader(buf *atomic.Buffer, offset int32, hdr *MessageHeader) *ImageFrame {
+	hdr.Wrap(buf, offset)
+	hdr.SetBlockLength(ImageFrameBlockLength)
+	hdr.SetTemplateID(ImageFrameTemplateID)
+	hdr.SetSchemaID(SchemaID)
+	hdr.SetVersion(SchemaVersion)
+	return m.WrapForEncode(buf, offset+MessageHeaderEncodedLength)
+}
+
+// WrapForDecode wraps m around the message at offset in buf, encoded with
+// the block length and schema version of its MessageHeader.
+func (m *ImageFrame) WrapForDecode(buf *atomic.Buffer, offset, actingBlockLength int32, actingVersion uint16) *ImageFrame {
+	m.buf = buf
+	m.offset = offset
+	m.limit = offset + actingBlockLength
+	m.actingVersion = actingVersion
+	return m
+}
+
+// Offset returns the offset of the message in its buffer.
+func (m *ImageFrame) Offset() int32 {
+	return m.offset
+}
+
+// Limit returns the offset in the buffer the next variable length field
+// starts at, or the end of the message once they have all been accessed.
+func (m *ImageFrame) Limit() int32 {
+	return m.limit
+}
+
+// SetLimit sets the offset the next variable length field starts at.
+func (m *ImageFrame) SetLimit(limit int32) {
+	m.limit = limit
+}
+
+// EncodedLength returns the length of the message up to its limit.
+func (m *ImageFrame) EncodedLength() int32 {
+	return m.limit - m.offset
+}
+
+// ActingVersion returns the schema version the message is decoded with.
+func (m *ImageFrame) ActingVersion() uint16 {
+	return m.actingVersion
+}
+
+// TimestampNs returns the timestampNs field, the host CLOCK_REALTIME the
+// frame was received at in ns (id 1).
+func (m *ImageFrame) TimestampNs() int64 {
+	return m.buf.GetInt64(m.offset)
+}
+
+// SetTimestampNs sets the TimestampNs field.
+func (m *ImageFrame) SetTimestampNs(v int64) {
+	m.buf.PutInt64(m.offset, v)
+}
+
+// SequenceNumber returns the sequenceNumber field, the frame count since the
+// service started, with gaps for frames the camera dropped (id 2).
+func (m *ImageFrame) SequenceNumber() int64 {
+	return m.buf.GetInt64(m.offset + 8)
+}
+
+// SetSequenceNumber sets the SequenceNumber field.
+func (m *ImageFrame) SetSequenceNumber(v int64) {
+	m.buf.PutInt64(m.offset+8, v)
+}
+
+// PayloadType returns the payloadType field (id 3).
+func (m *ImageFrame) PayloadType() PayloadType {
+	return PayloadType(m.buf.GetInt32(m.offset + 16))
+}
+
+// SetPayloadType sets the PayloadType field.
+func (m *ImageFrame) SetPayloadType(v PayloadType) {
+	m.buf.PutInt32(m.offset+16, int32(v))
+}
+
+// Format returns the format field (id 4).
+func (m *ImageFrame) Format() PixelFormat {
+	return PixelFormat(m.buf.GetInt32(m.offset + 20))
+}
+
+// SetFormat sets the Format field.
+func (m *ImageFrame) SetFormat(v PixelFormat) {
+	m.buf.PutInt32(m.offset+20, int32(v))
+}
+
+// SizeX returns the sizeX field, the image width in pixels (id 5).
+func (m *ImageFrame) SizeX() int32 {
+	return m.buf.GetInt32(m.offset + 24)
+}
+
+// SetSizeX sets the SizeX field.
+func (m *ImageFrame) SetSizeX(v int32) {
+	m.buf.PutInt32(m.offset+24, v)
+}
+
+// SizeY returns the sizeY field, the image height in pixels (id 6).
+func (m *ImageFrame) SizeY() int32 {
+	return m.buf.GetInt32(m.offset + 28)
+}
+
+// SetSizeY sets the SizeY field.
+func (m *ImageFrame) SetSizeY(v int32) {
+	m.buf.PutInt32(m.offset+28, v)
+}
+
+// OffsetX returns the offsetX field, the horizontal offset of the image on
+// the sensor (id 7).
+func (m *ImageFrame) OffsetX() int32 {
+	return m.buf.GetInt32(m.offset + 32)
+}
+
+// SetOffsetX sets the OffsetX field.
+func (m *ImageFrame) SetOffsetX(v int32) {
+	m.buf.PutInt32(m.offset+32, v)
+}
+
+// OffsetY returns the offsetY field, the vertical offset of the image on the
+// sensor (id 8).
+func (m *ImageFrame) OffsetY() int32 {
+	return m.buf.GetInt32(m.offset + 36)
+}
+
+// SetOffsetY sets the OffsetY field.
+func (m *ImageFrame) SetOffsetY(v int32) {
+	m.buf.PutInt32(m.offset+36, v)
+}
+
+// PaddingX returns the paddingX field, the padding bytes at the end of each
+// row (id 9).
+func (m *ImageFrame) PaddingX() int32 {
+	return m.buf.GetInt32(m.offset + 40)
+}
+
+// SetPaddingX sets the PaddingX field.
+func (m *ImageFrame) SetPaddingX(v int32) {
+	m.buf.PutInt32(m.offset+40, v)
+}
+
+// PaddingY returns the paddingY field, the padding bytes at the end of the
+// image (id 10).
+func (m *ImageFrame) PaddingY() int32 {
+	return m.buf.GetInt32(m.offset + 44)
+}
+
+// SetPaddingY sets the PaddingY field.
+func (m *ImageFrame) SetPaddingY(v int32) {
+	m.buf.PutInt32(m.offset+44, v)
+}
+
+// MetadataLength returns the length of the Metadata field at the limit,
+// without advancing it.
+func (m *ImageFrame) MetadataLength() uint32 {
+	return uint32(m.buf.GetInt32(m.limit))
+}
+
+// SkipMetadata advances the limit past the Metadata field and returns its
+// length.
+func (m *ImageFrame) SkipMetadata() uint32 {
+	n := uint32(m.buf.GetInt32(m.limit))
+	m.limit += ImageFrameMetadataHeaderLength + int32(n)
+	return n
+}
+
+// Metadata returns the metadata field, the typed key/value entries of the
+// acquisition settings (id 11).
+//
+// The returned slice aliases the buffer. It is nil if the field extends past
+// the buffer, in which case the limit is past the buffer too.
+func (m *ImageFrame) Metadata() []byte {
+	n := int64(uint32(m.buf.GetInt32(m.limit)))
+	offset := m.limit + ImageFrameMetadataHeaderLength
+	if int64(offset)+n > int64(m.buf.Capacity()) {
+		m.limit = m.buf.Capacity() + 1
+		return nil
+	}
+	m.limit = offset + int32(n)
+	return m.buf.GetBytesArray(offset, int32(n))
+}
+
+// PutMetadata encodes the Metadata field from src at the limit.
+func (m *ImageFrame) PutMetadata(src []byte) {
+	offset := m.PutMetadataLength(uint32(len(src)))
+	if len(src) > 0 {
+		m.buf.PutBytesArray(offset, &src, 0, int32(len(src)))
+	}
+}
+
+// PutMetadataLength encodes the length prefix of the Metadata field for n
+// bytes at the limit, advances the limit past the field and returns the
+// offset of its data, which the caller writes or sends separately.
+func (m *ImageFrame) PutMetadataLength(n uint32) int32 {
+	m.buf.PutInt32(m.limit, int32(n))
+	offset := m.limit + ImageFrameMetadataHeaderLength
+	m.limit = offset + int32(n)
+	return offset
+}
+
+// ImageLength returns the length of the Image field at the limit,
+// without advancing it.
+func (m *ImageFrame) ImageLength() uint32 {
+	return uint32(m.buf.GetInt32(m.limit))
+}
+
+// SkipImage advances the limit past the Image field and returns its
+// length.
+func (m *ImageFrame) SkipImage() uint32 {
+	n := uint32(m.buf.GetInt32(m.limit))
+	m.limit += ImageFrameImageHeaderLength + int32(n)
+	return n
+}
+
+// Image returns the image field, the pixels in row-major order,
+// little-endian (id 12).
+//
+// The returned slice aliases the buffer. It is nil if the field extends past
+// the buffer, in which case the limit is past the buffer too.
+func (m *ImageFrame) Image() []byte {
+	n := int64(uint32(m.buf.GetInt32(m.limit)))
+	offset := m.limit + ImageFrameImageHeaderLength
+	if int64(offset)+n > int64(m.buf.Capacity()) {
+		m.limit = m.buf.Capacity() + 1
+		return nil
+	}
+	m.limit = offset + int32(n)
+	return m.buf.GetBytesArray(offset, int32(n))
+}
+
+// PutImage encodes the Image field from src at the limit.
+func (m *ImageFrame) PutImage(src []byte) {
+	offset := m.PutImageLength(uint32(len(src)))
+	if len(src) > 0 {
+		m.buf.PutBytesArray(offset, &src, 0, int32(len(src)))
+	}
+}
+
+// PutImageLength encodes the length prefix of the Image field for n
+// bytes at the limit, advances the limit past the field and returns the
+// offset of its data, which the caller writes or sends separately.
+func (m *ImageFrame) PutImageLength(n uint32) int32 {
+	m.buf.PutInt32(m.limit, int32(n))
+	offset := m.limit + ImageFrameImageHeaderLength
+	m.limit = offset + int32(n)
+	return offset
+}
@@ -0,0 +1,66 @@
+package framecodec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/lirm/aeron-go/aeron/atomic"
+)
+
+// TestImageFrameWireFormat checks the encoded frame against the layout the
+// SBE specification gives _sbe/frame.xml, written out independently of the
+// generator: the fields are little-endian at their natural offsets, the
+// enums take the size of their encoding types and the data fields are
+// prefixed with their uint32 lengths.
+func TestImageFrameWireFormat(t *testing.T) {
+	metadata := []byte{1, 2, 3}
+	image := []byte{4, 5, 6, 7}
+
+	var want []byte
+	le := binary.LittleEndian
+	want = le.AppendUint16(want, 48)   // blockLength
+	want = le.AppendUint16(want, 1)    // templateId
+	want = le.AppendUint16(want, 1001) // schemaId
+	want = le.AppendUint16(want, 1)    // version
+	want = le.AppendUint64(want, uint64(1234567890123))
+	want = le.AppendUint64(want, 42)
+	want = le.AppendUint32(want, 0)        // payloadType Image
+	want = le.AppendUint32(want, 17825799) // format Mono16
+	for _, v := range []int32{640, 512, 16, 8, 0, 0} {
+		want = le.AppendUint32(want, uint32(v))
+	}
+	want = le.AppendUint32(want, uint32(len(metadata)))
+	want = append(want, metadata...)
+	want = le.AppendUint32(want, uint32(len(image)))
+	want = append(want, image...)
+
+	got := make([]byte, len(want))
+	buf := atomic.MakeBuffer(got)
+	var hdr MessageHeader
+	var frame ImageFrame
+	frame.WrapAndApplyHeader(buf, 0, &hdr)
+	frame.SetTimestampNs(1234567890123)
+	frame.SetSequenceNumber(42)
+	frame.SetPayloadType(PayloadTypeImage)
+	frame.SetFormat(PixelFormatMono16)
+	frame.SetSizeX(640)
+	frame.SetSizeY(512)
+	frame.SetOffsetX(16)
+	frame.SetOffsetY(8)
+	frame.PutMetadata(metadata)
+	frame.PutImage(image)
+
+	if n := MessageHeaderEncodedLength + frame.EncodedLength(); n != int32(len(want)) {
+		t.Errorf("encoded length %d, want %d", n, len(want))
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("encoded\n% x\nwant\n% x", got, want)
+	}
+
+	frame.WrapForDecode(buf, MessageHeaderEncodedLength, int32(hdr.BlockLength()), hdr.Version())
+	if frame.Format() != PixelFormatMono16 || !bytes.Equal(frame.Metadata(), metadata) ||
+		!bytes.Equal(frame.Image(), image) {
+		t.Errorf("decoded format %v, metadata % x, image % x", frame.Format(), frame.Metadata(), frame.Image())
+	}
+}
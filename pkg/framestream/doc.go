@@ -0,0 +1,24 @@
+// Package framestream subscribes to the frames published by the FLI camera
+// service.
+//
+// A Subscriber reassembles fragmented frames, decodes them with the
+// framecodec package, checks that they hold Mono16 images and hands them to
+// a Handler, counting the frames missing from the sequence numbers and the
+// latency from the frame timestamps:
+//
+//	sub, err := framestream.Subscribe(client, framestream.Config{
+//		Channel:  "aeron:ipc",
+//		StreamID: 1001,
+//	}, func(f *framestream.Frame) {
+//		img := f.View() // or f.Gray16(dst) to keep a copy
+//		_ = img.Gray16At(0, 0)
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer sub.Close()
+//	return sub.Run(ctx)
+//
+// Frames alias the receive buffer and must be copied to be kept past the
+// Handler.
+package framestream
@@ -0,0 +1,197 @@
+package framestream
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	"github.com/lirm/aeron-go/aeron/atomic"
+
+	"github.com/New-Earth-Lab/flicameraservice/pkg/framecodec"
+)
+
+// ErrInvalidFrame is wrapped by the errors of messages that are not valid
+// frames.
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// Frame is a decoded frame message.
+type Frame struct {
+	TimestampNs    int64
+	SequenceNumber int64
+	PayloadType    framecodec.PayloadType
+	Format         framecodec.PixelFormat
+	Width          int
+	Height         int
+	OffsetX        int
+	OffsetY        int
+	// PaddingX is the number of padding bytes at the end of each row and
+	// PaddingY at the end of the image.
+	PaddingX int
+	PaddingY int
+	// SchemaVersion is the version of the schema the frame was encoded
+	// with.
+	SchemaVersion uint16
+
+	// Metadata holds the metadata entries of the frame and Pixels the
+	// little-endian Mono16 rows. Both alias the receive buffer and are only
+	// valid until the handler returns.
+	Metadata []byte
+	Pixels   []byte
+
+	// Received is the time the Subscriber received the frame at and Latency
+	// the time since TimestampNs, which needs the clocks of the publisher
+	// and subscriber hosts to be synchronized.
+	Received time.Time
+	Latency  time.Duration
+	// Dropped is the number of frames missing between the previous frame
+	// and this one.
+	Dropped int64
+}
+
+// Timestamp returns the time the camera service received the frame at.
+func (f *Frame) Timestamp() time.Time {
+	return time.Unix(0, f.TimestampNs)
+}
+
+// Stride returns the length of a row of pixels in bytes.
+func (f *Frame) Stride() int {
+	return 2*f.Width + f.PaddingX
+}
+
+// View returns a view of the pixels as an image without copying them.
+func (f *Frame) View() *Gray16View {
+	return &Gray16View{
+		Pix:    f.Pixels,
+		Stride: f.Stride(),
+		Rect:   image.Rect(0, 0, f.Width, f.Height),
+	}
+}
+
+// Gray16 copies the pixels to dst, which is allocated if it is nil or not
+// of the frame size, and returns it.
+func (f *Frame) Gray16(dst *image.Gray16) *image.Gray16 {
+	r := image.Rect(0, 0, f.Width, f.Height)
+	if dst == nil || dst.Rect != r {
+		dst = image.NewGray16(r)
+	}
+	stride := f.Stride()
+	for y := 0; y < f.Height; y++ {
+		src := f.Pixels[y*stride : y*stride+2*f.Width]
+		row := dst.Pix[y*dst.Stride : y*dst.Stride+2*f.Width]
+		// image.Gray16 is big-endian
+		for i := 0; i < len(src); i += 2 {
+			row[i], row[i+1] = src[i+1], src[i]
+		}
+	}
+	return dst
+}
+
+// Decode decodes the frame message of length bytes at offset in buf into f
+// and checks that it holds a Mono16 image. The slices of f alias buf. The
+// fields set by the Subscriber are left unchanged.
+func Decode(buf *atomic.Buffer, offset, length int32, f *Frame) error {
+	var hdr framecodec.MessageHeader
+	var frame framecodec.ImageFrame
+
+	if length < framecodec.MessageHeaderEncodedLength {
+		return fmt.Errorf("%w: %d byte message", ErrInvalidFrame, length)
+	}
+	hdr.Wrap(buf, offset)
+	if id := hdr.SchemaID(); id != framecodec.SchemaID {
+		return fmt.Errorf("%w: schema %d, expected %d", ErrInvalidFrame, id, framecodec.SchemaID)
+	}
+	if id := hdr.TemplateID(); id != framecodec.ImageFrameTemplateID {
+		return fmt.Errorf("%w: template %d, expected %d", ErrInvalidFrame, id, framecodec.ImageFrameTemplateID)
+	}
+	blockLength := int32(hdr.BlockLength())
+	if blockLength < framecodec.ImageFrameBlockLength ||
+		length < framecodec.MessageHeaderEncodedLength+blockLength {
+		return fmt.Errorf("%w: %d byte block in a %d byte message", ErrInvalidFrame, blockLength, length)
+	}
+	frame.WrapForDecode(buf, offset+framecodec.MessageHeaderEncodedLength, blockLength, hdr.Version())
+
+	f.TimestampNs = frame.TimestampNs()
+	f.SequenceNumber = frame.SequenceNumber()
+	f.PayloadType = frame.PayloadType()
+	f.Format = frame.Format()
+	f.Width = int(frame.SizeX())
+	f.Height = int(frame.SizeY())
+	f.OffsetX = int(frame.OffsetX())
+	f.OffsetY = int(frame.OffsetY())
+	f.PaddingX = int(frame.PaddingX())
+	f.PaddingY = int(frame.PaddingY())
+	f.SchemaVersion = hdr.Version()
+	f.Metadata, f.Pixels = nil, nil
+
+	if f.PayloadType != framecodec.PayloadTypeImage {
+		return fmt.Errorf("%w: payload type %v", ErrInvalidFrame, f.PayloadType)
+	}
+	if f.Format != framecodec.PixelFormatMono16 {
+		return fmt.Errorf("%w: pixel format %v", ErrInvalidFrame, f.Format)
+	}
+	if f.Width < 0 || f.Height < 0 || f.PaddingX < 0 || f.PaddingY < 0 {
+		return fmt.Errorf("%w: %dx%d image with padding %d, %d",
+			ErrInvalidFrame, f.Width, f.Height, f.PaddingX, f.PaddingY)
+	}
+
+	end := int64(offset) + int64(length)
+	if int64(frame.Limit())+framecodec.ImageFrameMetadataHeaderLength > end {
+		return fmt.Errorf("%w: truncated metadata", ErrInvalidFrame)
+	}
+	if n := frame.MetadataLength(); int64(frame.Limit())+framecodec.ImageFrameMetadataHeaderLength+int64(n) > end {
+		return fmt.Errorf("%w: %d bytes of metadata past the message", ErrInvalidFrame, n)
+	}
+	f.Metadata = frame.Metadata()
+	if int64(frame.Limit())+framecodec.ImageFrameImageHeaderLength > end {
+		return fmt.Errorf("%w: truncated image", ErrInvalidFrame)
+	}
+	n := int64(frame.ImageLength())
+	if int64(frame.Limit())+framecodec.ImageFrameImageHeaderLength+n > end {
+		return fmt.Errorf("%w: %d byte image past the message", ErrInvalidFrame, n)
+	}
+	if expected := int64(2*f.Width+f.PaddingX)*int64(f.Height) + int64(f.PaddingY); n != expected {
+		return fmt.Errorf("%w: %d byte image, expected %d for %dx%d",
+			ErrInvalidFrame, n, expected, f.Width, f.Height)
+	}
+	f.Pixels = frame.Image()
+	return nil
+}
+
+// Gray16View is an image of little-endian Mono16 pixels, such as those of
+// a Frame, read in place.
+type Gray16View struct {
+	// Pix holds the pixels as little-endian uint16. The pixel at (x, y)
+	// starts at Pix[(y-Rect.Min.Y)*Stride + (x-Rect.Min.X)*2].
+	Pix    []byte
+	Stride int
+	Rect   image.Rectangle
+}
+
+func (v *Gray16View) ColorModel() color.Model {
+	return color.Gray16Model
+}
+
+func (v *Gray16View) Bounds() image.Rectangle {
+	return v.Rect
+}
+
+func (v *Gray16View) At(x, y int) color.Color {
+	return v.Gray16At(x, y)
+}
+
+// Gray16At returns the pixel at (x, y), or black outside the bounds.
+func (v *Gray16View) Gray16At(x, y int) color.Gray16 {
+	if !(image.Point{x, y}.In(v.Rect)) {
+		return color.Gray16{}
+	}
+	i := v.PixOffset(x, y)
+	return color.Gray16{Y: uint16(v.Pix[i]) | uint16(v.Pix[i+1])<<8}
+}
+
+// PixOffset returns the index of the first byte of the pixel at (x, y) in
+// Pix.
+func (v *Gray16View) PixOffset(x, y int) int {
+	return (y-v.Rect.Min.Y)*v.Stride + (x-v.Rect.Min.X)*2
+}
@@ -0,0 +1,201 @@
+package framestream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lirm/aeron-go/aeron"
+	"github.com/lirm/aeron-go/aeron/atomic"
+	"github.com/lirm/aeron-go/aeron/idlestrategy"
+	"github.com/lirm/aeron-go/aeron/logbuffer"
+	"github.com/lirm/aeron-go/aeron/logbuffer/term"
+)
+
+// DefaultFragmentLimit is the default number of fragments handled per poll.
+const DefaultFragmentLimit = 16
+
+// maxParkNs bounds the latency the idle Run loop adds to a frame.
+const maxParkNs = int64(50 * time.Microsecond)
+
+// Subscription is the part of an aeron.Subscription the Subscriber polls.
+type Subscription interface {
+	Poll(handler term.FragmentHandler, fragmentLimit int) int
+	IsConnected() bool
+	Close() error
+}
+
+// Handler handles a valid frame. The frame and its slices are only valid
+// until it returns.
+type Handler func(f *Frame)
+
+// Config configures a Subscriber.
+type Config struct {
+	Channel  string
+	StreamID int32
+	// Decimation is the decimation factor of the service output subscribed
+	// to, so that the frames it skips are not counted as dropped. Zero
+	// means 1.
+	Decimation int
+	// FragmentLimit bounds the fragments handled per poll. Zero means
+	// DefaultFragmentLimit.
+	FragmentLimit int
+	// OnInvalid, if set, is called with the error of each message that is
+	// not a valid frame. Such messages are counted and skipped.
+	OnInvalid func(err error)
+}
+
+// Stats are the frame counts and latencies of a Subscriber.
+type Stats struct {
+	// Frames is the number of valid frames handled.
+	Frames uint64
+	// Invalid is the number of messages that were not valid frames.
+	Invalid uint64
+	// Gaps is the number of sequence gaps and Dropped the number of frames
+	// missing in them.
+	Gaps    uint64
+	Dropped uint64
+	// Restarts is the number of times the sequence went backwards, as it
+	// does when the camera service restarts.
+	Restarts     uint64
+	LastSequence int64
+	// Latencies from TimestampNs to the reception of the frames
+	Latency     time.Duration
+	LatencyMin  time.Duration
+	LatencyMax  time.Duration
+	LatencyMean time.Duration
+}
+
+// Subscriber receives frames from a camera stream, reassembling fragmented
+// frames, and tracks their sequence numbers and latency. Poll and Run must
+// not be called concurrently; Stats can be called from any goroutine.
+type Subscriber struct {
+	subscription  Subscription
+	assembler     *aeron.FragmentAssembler
+	handler       Handler
+	onInvalid     func(error)
+	decimation    int64
+	fragmentLimit int
+
+	frame        Frame
+	haveSequence bool
+
+	mu           sync.Mutex
+	stats        Stats
+	latencyTotal time.Duration
+}
+
+// Subscribe adds a subscription to the camera stream of config with client
+// and returns its Subscriber.
+func Subscribe(client *aeron.Aeron, config Config, handler Handler) (*Subscriber, error) {
+	subscription, err := client.AddSubscription(config.Channel, config.StreamID)
+	if err != nil {
+		return nil, fmt.Errorf("framestream: subscription %s stream %d: %w", config.Channel, config.StreamID, err)
+	}
+	return NewSubscriber(subscription, config, handler), nil
+}
+
+// NewSubscriber returns a Subscriber of the frames of subscription. The
+// Channel and StreamID of config are not used.
+func NewSubscriber(subscription Subscription, config Config, handler Handler) *Subscriber {
+	s := &Subscriber{
+		subscription:  subscription,
+		handler:       handler,
+		onInvalid:     config.OnInvalid,
+		decimation:    int64(config.Decimation),
+		fragmentLimit: config.FragmentLimit,
+	}
+	if s.decimation <= 0 {
+		s.decimation = 1
+	}
+	if s.fragmentLimit <= 0 {
+		s.fragmentLimit = DefaultFragmentLimit
+	}
+	s.assembler = aeron.NewFragmentAssembler(s.onMessage, aeron.DefaultFragmentAssemblyBufferLength)
+	return s
+}
+
+// Poll handles the frames available, up to the fragment limit, and returns
+// the number of fragments read.
+func (s *Subscriber) Poll() int {
+	return s.subscription.Poll(s.assembler.OnFragment, s.fragmentLimit)
+}
+
+// Run polls until ctx is done.
+func (s *Subscriber) Run(ctx context.Context) error {
+	idle := idlestrategy.NewBackoffIdleStrategy(idlestrategy.DefaultMaxSpins,
+		idlestrategy.DefaultMaxYields, idlestrategy.DefaultMinParkNs, maxParkNs)
+	for ctx.Err() == nil {
+		idle.Idle(s.Poll())
+	}
+	return nil
+}
+
+// IsConnected reports whether a publisher is connected to the subscription.
+func (s *Subscriber) IsConnected() bool {
+	return s.subscription.IsConnected()
+}
+
+// Close closes the subscription.
+func (s *Subscriber) Close() error {
+	return s.subscription.Close()
+}
+
+// Stats returns the frame counts and latencies so far.
+func (s *Subscriber) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.stats
+}
+
+func (s *Subscriber) onMessage(buf *atomic.Buffer, offset, length int32, _ *logbuffer.Header) {
+	f := &s.frame
+	if err := Decode(buf, offset, length, f); err != nil {
+		s.mu.Lock()
+		s.stats.Invalid++
+		s.mu.Unlock()
+		if s.onInvalid != nil {
+			s.onInvalid(err)
+		}
+		return
+	}
+	f.Received = time.Now()
+	f.Latency = f.Received.Sub(f.Timestamp())
+	s.track(f)
+	s.handler(f)
+}
+
+// track updates the stats with f and sets its Dropped count.
+func (s *Subscriber) track(f *Frame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := &s.stats
+	f.Dropped = 0
+	switch {
+	case !s.haveSequence:
+		s.haveSequence = true
+	case f.SequenceNumber <= st.LastSequence:
+		st.Restarts++
+	default:
+		if missing := (f.SequenceNumber-st.LastSequence)/s.decimation - 1; missing > 0 {
+			f.Dropped = missing
+			st.Gaps++
+			st.Dropped += uint64(missing)
+		}
+	}
+	st.LastSequence = f.SequenceNumber
+
+	st.Frames++
+	st.Latency = f.Latency
+	if st.Frames == 1 || f.Latency < st.LatencyMin {
+		st.LatencyMin = f.Latency
+	}
+	if st.Frames == 1 || f.Latency > st.LatencyMax {
+		st.LatencyMax = f.Latency
+	}
+	s.latencyTotal += f.Latency
+	st.LatencyMean = s.latencyTotal / time.Duration(st.Frames)
+}